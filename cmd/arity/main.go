@@ -0,0 +1,140 @@
+// Command arity generates arbitrary-arity variants of ErrorFn, W and ExceptFn
+// for package errors, and of GetResult for package result.
+//
+// Usage (through go generate):
+//
+//	//go:generate go run ../cmd/arity -pkg errors -max 4
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Arity of generated function.
+type arity struct {
+	N     int
+	Types []string
+	Vars  []string
+}
+
+// Return parameter list, e.g. "t1 T1, t2 T2".
+func (a arity) Params() string {
+	params := make([]string, len(a.Vars))
+	for i := range a.Vars {
+		params[i] = a.Vars[i] + " " + a.Types[i]
+	}
+
+	return strings.Join(params, ", ")
+}
+
+// Input of templates.
+type data struct {
+	Package string
+	Arities []arity
+}
+
+// Templates for each supported package, first source, then test.
+var templates = map[string][2]*template.Template{
+	"errors": {parse("errors", errorsSource), parse("errors_test", errorsTest)},
+	"result": {parse("result", resultSource), parse("result_test", resultTest)},
+}
+
+// Lowest arity generated for each supported package.
+// Lower ones are written by hand.
+var minArity = map[string]int{
+	"errors": 3,
+	"result": 2,
+}
+
+// Parse template with helper functions.
+func parse(name, text string) *template.Template {
+	return template.Must(template.New(name).Funcs(template.FuncMap{
+		"join": strings.Join,
+		"inc":  func(i int) int { return i + 1 },
+		"repeat": func(s string, n int) []string {
+			out := make([]string, n)
+			for i := range out {
+				out[i] = s
+			}
+
+			return out
+		},
+		"numbered": func(prefix string, n int) []string {
+			out := make([]string, n)
+			for i := range out {
+				out[i] = fmt.Sprintf("%s%d", prefix, i+1)
+			}
+
+			return out
+		},
+	}).Parse(text))
+}
+
+// Create arities from lo to hi (inclusive).
+func arities(lo, hi int) []arity {
+	var out []arity
+	for n := lo; n <= hi; n++ {
+		a := arity{N: n}
+		for i := 1; i <= n; i++ {
+			a.Types = append(a.Types, fmt.Sprintf("T%d", i))
+			a.Vars = append(a.Vars, fmt.Sprintf("t%d", i))
+		}
+
+		out = append(out, a)
+	}
+
+	return out
+}
+
+// Render source and test of package pkg for arities up to limit.
+func generate(pkg string, limit int) (source, test []byte, err error) {
+	tmpl, ok := templates[pkg]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported package: %q", pkg)
+	}
+
+	if limit < minArity[pkg] {
+		return nil, nil, fmt.Errorf("max arity of package %q must be at least %d, got: %d", pkg, minArity[pkg], limit)
+	}
+
+	d := data{Package: pkg, Arities: arities(minArity[pkg], limit)}
+	for i, out := range []*[]byte{&source, &test} {
+		var buffer bytes.Buffer
+		if err := tmpl[i].Execute(&buffer, d); err != nil {
+			return nil, nil, err
+		}
+
+		if *out, err = format.Source(buffer.Bytes()); err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", tmpl[i].Name(), err)
+		}
+	}
+
+	return source, test, nil
+}
+
+func main() {
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "target package (errors or result)")
+	limit := flag.Int("max", 4, "highest arity to generate")
+	out := flag.String("out", "arity_gen", "base name of generated files")
+	flag.Parse()
+
+	source, test, err := generate(*pkg, *limit)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	for name, content := range map[string][]byte{*out + ".go": source, *out + "_test.go": test} {
+		if err := os.WriteFile(filepath.Clean(name), content, 0o644); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		pkg  string
+		max  int
+	}{
+		{"test#1", "errors", 4},
+		{"test#2", "result", 4},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			source, test, err := generate(tt.pkg, tt.max)
+			if err != nil {
+				t.Fatalf(`generate(%q, %d) failed: %v`, tt.pkg, tt.max, err)
+			}
+
+			for name, got := range map[string][]byte{"arity_gen.go": source, "arity_gen_test.go": test} {
+				want, err := os.ReadFile(filepath.Join("..", "..", tt.pkg, name))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !bytes.Equal(got, want) {
+					t.Errorf(`generate(%q, %d) failed: %s is out of date, run go generate ./...`, tt.pkg, tt.max, name)
+				}
+			}
+		})
+	}
+
+	if _, _, err := generate("unknown", 4); err == nil {
+		t.Errorf(`generate("unknown", 4) failed: got nil error`)
+	}
+
+	for pkg, limit := range minArity {
+		if _, _, err := generate(pkg, limit-1); err == nil {
+			t.Errorf(`generate(%q, %d) failed: got nil error`, pkg, limit-1)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+// Header of generated files.
+const header = `// Code generated by cmd/arity; DO NOT EDIT.
+
+`
+
+// Source of package errors.
+const errorsSource = header + `package {{ .Package }}
+
+type (
+{{- range $i, $a := .Arities }}
+{{ if $i }}
+{{ end -}}
+	// Function of type func[{{ join .Types ", " }} any]() ({{ join .Types ", " }}, error).
+	ErrorFn{{ .N }}[{{ join .Types ", " }} any] func() ({{ join .Types ", " }}, error)
+{{- end }}
+)
+{{ range .Arities }}
+// Wrapper for function of type func[{{ join .Types ", " }} any]() ({{ join .Types ", " }}, error).
+func W{{ .N }}[{{ join .Types ", " }} any]({{ .Params }}, err error) ErrorFn{{ .N }}[{{ join .Types ", " }}] {
+	return func() ({{ join .Types ", " }}, error) { return {{ join .Vars ", " }}, err }
+}
+
+// Handle error if not nil, and not among ignored ones.
+// Return anything from fn except for error if successful.
+func ExceptFn{{ .N }}[{{ join .Types ", " }} any](fn ErrorFn{{ .N }}[{{ join .Types ", " }}], ignore ...error) ({{ join .Types ", " }}) {
+	return ExceptFn{{ .N }}In(defaultScope, fn, ignore...)
+}
+
+// Handle error through handler if not nil, and not among ignored ones.
+// Return anything from fn except for error if successful.
+// Go does not support generic methods, hence the handler is passed explicitly.
+func ExceptFn{{ .N }}In[{{ join .Types ", " }} any](h Handler, fn ErrorFn{{ .N }}[{{ join .Types ", " }}], ignore ...error) ({{ join .Types ", " }}) {
+	{{ join .Vars ", " }}, err := fn()
+	h.Except(err, ignore...)
+	return {{ join .Vars ", " }}
+}
+{{ end -}}
+`
+
+// Test of package errors.
+const errorsTest = header + `package {{ .Package }}
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+{{ range .Arities }}
+func TestExceptFn{{ .N }}(t *testing.T) {
+	type args struct {
+		fn     ErrorFn{{ .N }}[{{ join (repeat "any" .N) ", " }}]
+		ignore []error
+	}
+	for _, tt := range []struct {
+		name string
+		args args
+		want string
+	}{
+		{"test#1", args{func() ({{ join (repeat "any" .N) ", " }}, error) { return {{ join (repeat "&struct{}{}" .N) ", " }}, nil }, nil}, ""},
+		{"test#2", args{func() ({{ join (repeat "any" .N) ", " }}, error) { return {{ join (repeat "nil" .N) ", " }}, os.ErrExist }, nil}, os.ErrExist.Error()},
+		{"test#3", args{func() ({{ join (repeat "any" .N) ", " }}, error) { return {{ join (repeat "&struct{}{}" .N) ", " }}, os.ErrExist }, []error{os.ErrExist}}, ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := bytes.NewBuffer(nil)
+			RegisterCallback(func(err error) { buffer.WriteString(err.Error()) })
+			defer RestoreCallback()
+
+			{{ join (numbered "ret" .N) ", " }} := ExceptFn{{ .N }}(tt.args.fn, tt.args.ignore...)
+			got := buffer.String()
+
+			if got != tt.want {
+				t.Errorf(` + "`" + `ExceptFn{{ .N }}(%p, %v) failed: got: %q, want: %q` + "`" + `, tt.args.fn, tt.args.ignore, got, tt.want)
+			}
+
+			if got == "" && ({{ join (numbered "ret" .N) " == nil || " }} == nil) {
+				t.Errorf(` + "`" + `ExceptFn{{ .N }}(%p, %v) failed: got nil` + "`" + `, tt.args.fn, tt.args.ignore)
+			}
+		})
+	}
+}
+
+func TestW{{ .N }}(t *testing.T) {
+	{{ join .Vars ", " }}, err := W{{ .N }}({{ range .Vars }}"{{ . }}", {{ end }}os.ErrExist)()
+	if {{ range .Vars }}{{ . }} != "{{ . }}" || {{ end }}err != os.ErrExist {
+		t.Errorf(` + "`" + `W{{ .N }}() failed: got: %v` + "`" + `, []any{ {{- join .Vars ", " }}, err})
+	}
+}
+{{ end -}}
+`
+
+// Source of package result.
+const resultSource = header + `package {{ .Package }}
+
+import (
+	supererrors "github.com/sarumaj/go-super/errors"
+)
+{{ range .Arities }}
+// Tuple of {{ .N }} values.
+type Tuple{{ .N }}[{{ join .Types ", " }} any] struct {
+{{- range $i, $t := .Types }}
+	V{{ inc $i }} {{ $t }}
+{{- end }}
+}
+
+// Get result of function returning {{ .N }} values and error.
+func GetResult{{ .N }}[{{ join .Types ", " }} any](fn supererrors.ErrorFn{{ .N }}[{{ join .Types ", " }}], ignore ...error) *Result[Tuple{{ .N }}[{{ join .Types ", " }}]] {
+	return GetResult(func() (Tuple{{ .N }}[{{ join .Types ", " }}], error) {
+		{{ join .Vars ", " }}, err := fn()
+		return Tuple{{ .N }}[{{ join .Types ", " }}]{ {{- range $i, $v := .Vars }}{{ if $i }}, {{ end }}V{{ inc $i }}: {{ $v }}{{ end -}} }, err
+	}, ignore...)
+}
+{{ end -}}
+`
+
+// Test of package result.
+const resultTest = header + `package {{ .Package }}
+
+import (
+	"errors"
+	"testing"
+)
+{{ range .Arities }}
+func TestGetResult{{ .N }}(t *testing.T) {
+	testError := errors.New("test")
+
+	type output = Tuple{{ .N }}[{{ join (repeat "any" .N) ", " }}]
+	type args struct {
+		o   output
+		err error
+	}
+
+	for _, tt := range []struct {
+		name   string
+		args   args
+		ignore []error
+		want   *Result[output]
+	}{
+		{"test#1", args{output{ {{- join (repeat "1" .N) ", " -}} }, nil}, nil, &Result[output]{state: Success, value: output{ {{- join (repeat "1" .N) ", " -}} }}},
+		{"test#2", args{output{}, testError}, nil, &Result[output]{state: Failure, fault: testError}},
+		{"test#3", args{output{}, testError}, []error{testError}, &Result[output]{state: ExpectedFailure}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GetResult{{ .N }}(func() ({{ join (repeat "any" .N) ", " }}, error) {
+				return {{ range $i, $v := .Vars }}{{ if $i }}, {{ end }}tt.args.o.V{{ inc $i }}{{ end }}, tt.args.err
+			}, tt.ignore...)
+			if !result.Equals(*tt.want) {
+				t.Errorf("GetResult{{ .N }}() = %v, want %v", result, tt.want)
+			}
+		})
+	}
+}
+{{ end -}}
+`
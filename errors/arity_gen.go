@@ -0,0 +1,51 @@
+// Code generated by cmd/arity; DO NOT EDIT.
+
+package errors
+
+type (
+	// Function of type func[T1, T2, T3 any]() (T1, T2, T3, error).
+	ErrorFn3[T1, T2, T3 any] func() (T1, T2, T3, error)
+
+	// Function of type func[T1, T2, T3, T4 any]() (T1, T2, T3, T4, error).
+	ErrorFn4[T1, T2, T3, T4 any] func() (T1, T2, T3, T4, error)
+)
+
+// Wrapper for function of type func[T1, T2, T3 any]() (T1, T2, T3, error).
+func W3[T1, T2, T3 any](t1 T1, t2 T2, t3 T3, err error) ErrorFn3[T1, T2, T3] {
+	return func() (T1, T2, T3, error) { return t1, t2, t3, err }
+}
+
+// Handle error if not nil, and not among ignored ones.
+// Return anything from fn except for error if successful.
+func ExceptFn3[T1, T2, T3 any](fn ErrorFn3[T1, T2, T3], ignore ...error) (T1, T2, T3) {
+	return ExceptFn3In(defaultScope, fn, ignore...)
+}
+
+// Handle error through handler if not nil, and not among ignored ones.
+// Return anything from fn except for error if successful.
+// Go does not support generic methods, hence the handler is passed explicitly.
+func ExceptFn3In[T1, T2, T3 any](h Handler, fn ErrorFn3[T1, T2, T3], ignore ...error) (T1, T2, T3) {
+	t1, t2, t3, err := fn()
+	h.Except(err, ignore...)
+	return t1, t2, t3
+}
+
+// Wrapper for function of type func[T1, T2, T3, T4 any]() (T1, T2, T3, T4, error).
+func W4[T1, T2, T3, T4 any](t1 T1, t2 T2, t3 T3, t4 T4, err error) ErrorFn4[T1, T2, T3, T4] {
+	return func() (T1, T2, T3, T4, error) { return t1, t2, t3, t4, err }
+}
+
+// Handle error if not nil, and not among ignored ones.
+// Return anything from fn except for error if successful.
+func ExceptFn4[T1, T2, T3, T4 any](fn ErrorFn4[T1, T2, T3, T4], ignore ...error) (T1, T2, T3, T4) {
+	return ExceptFn4In(defaultScope, fn, ignore...)
+}
+
+// Handle error through handler if not nil, and not among ignored ones.
+// Return anything from fn except for error if successful.
+// Go does not support generic methods, hence the handler is passed explicitly.
+func ExceptFn4In[T1, T2, T3, T4 any](h Handler, fn ErrorFn4[T1, T2, T3, T4], ignore ...error) (T1, T2, T3, T4) {
+	t1, t2, t3, t4, err := fn()
+	h.Except(err, ignore...)
+	return t1, t2, t3, t4
+}
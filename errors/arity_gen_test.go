@@ -0,0 +1,91 @@
+// Code generated by cmd/arity; DO NOT EDIT.
+
+package errors
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestExceptFn3(t *testing.T) {
+	type args struct {
+		fn     ErrorFn3[any, any, any]
+		ignore []error
+	}
+	for _, tt := range []struct {
+		name string
+		args args
+		want string
+	}{
+		{"test#1", args{func() (any, any, any, error) { return &struct{}{}, &struct{}{}, &struct{}{}, nil }, nil}, ""},
+		{"test#2", args{func() (any, any, any, error) { return nil, nil, nil, os.ErrExist }, nil}, os.ErrExist.Error()},
+		{"test#3", args{func() (any, any, any, error) { return &struct{}{}, &struct{}{}, &struct{}{}, os.ErrExist }, []error{os.ErrExist}}, ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := bytes.NewBuffer(nil)
+			RegisterCallback(func(err error) { buffer.WriteString(err.Error()) })
+			defer RestoreCallback()
+
+			ret1, ret2, ret3 := ExceptFn3(tt.args.fn, tt.args.ignore...)
+			got := buffer.String()
+
+			if got != tt.want {
+				t.Errorf(`ExceptFn3(%p, %v) failed: got: %q, want: %q`, tt.args.fn, tt.args.ignore, got, tt.want)
+			}
+
+			if got == "" && (ret1 == nil || ret2 == nil || ret3 == nil) {
+				t.Errorf(`ExceptFn3(%p, %v) failed: got nil`, tt.args.fn, tt.args.ignore)
+			}
+		})
+	}
+}
+
+func TestW3(t *testing.T) {
+	t1, t2, t3, err := W3("t1", "t2", "t3", os.ErrExist)()
+	if t1 != "t1" || t2 != "t2" || t3 != "t3" || err != os.ErrExist {
+		t.Errorf(`W3() failed: got: %v`, []any{t1, t2, t3, err})
+	}
+}
+
+func TestExceptFn4(t *testing.T) {
+	type args struct {
+		fn     ErrorFn4[any, any, any, any]
+		ignore []error
+	}
+	for _, tt := range []struct {
+		name string
+		args args
+		want string
+	}{
+		{"test#1", args{func() (any, any, any, any, error) { return &struct{}{}, &struct{}{}, &struct{}{}, &struct{}{}, nil }, nil}, ""},
+		{"test#2", args{func() (any, any, any, any, error) { return nil, nil, nil, nil, os.ErrExist }, nil}, os.ErrExist.Error()},
+		{"test#3", args{func() (any, any, any, any, error) {
+			return &struct{}{}, &struct{}{}, &struct{}{}, &struct{}{}, os.ErrExist
+		}, []error{os.ErrExist}}, ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := bytes.NewBuffer(nil)
+			RegisterCallback(func(err error) { buffer.WriteString(err.Error()) })
+			defer RestoreCallback()
+
+			ret1, ret2, ret3, ret4 := ExceptFn4(tt.args.fn, tt.args.ignore...)
+			got := buffer.String()
+
+			if got != tt.want {
+				t.Errorf(`ExceptFn4(%p, %v) failed: got: %q, want: %q`, tt.args.fn, tt.args.ignore, got, tt.want)
+			}
+
+			if got == "" && (ret1 == nil || ret2 == nil || ret3 == nil || ret4 == nil) {
+				t.Errorf(`ExceptFn4(%p, %v) failed: got nil`, tt.args.fn, tt.args.ignore)
+			}
+		})
+	}
+}
+
+func TestW4(t *testing.T) {
+	t1, t2, t3, t4, err := W4("t1", "t2", "t3", "t4", os.ErrExist)()
+	if t1 != "t1" || t2 != "t2" || t3 != "t3" || t4 != "t4" || err != os.ErrExist {
+		t.Errorf(`W4() failed: got: %v`, []any{t1, t2, t3, t4, err})
+	}
+}
@@ -0,0 +1,183 @@
+package errors
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Size of queue if not set in AsyncOptions.
+const DefaultQueueSize = 64
+
+// Behaviour of AsyncDispatcher when its queue is full.
+type OverflowPolicy int
+
+const (
+	// Wait until queue has room.
+	OverflowBlock OverflowPolicy = iota
+
+	// Drop oldest queued error to make room.
+	OverflowDropOldest
+
+	// Drop error being dispatched.
+	OverflowDropNewest
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "Block"
+
+	case OverflowDropOldest:
+		return "DropOldest"
+
+	case OverflowDropNewest:
+		return "DropNewest"
+
+	default:
+		return "Unknown"
+
+	}
+}
+
+// Options of AsyncDispatcher.
+type AsyncOptions struct {
+	// Capacity of queue, defaults to DefaultQueueSize.
+	QueueSize int
+
+	// Number of workers calling wrapped callback, defaults to 1.
+	Workers int
+
+	// Behaviour when queue is full, defaults to OverflowBlock.
+	Overflow OverflowPolicy
+}
+
+// Callback wrapper dispatching errors asynchronously through bounded queue (thread-safe).
+// With a single worker, errors are passed in order of occurrence.
+type AsyncDispatcher struct {
+	next     Callback
+	overflow OverflowPolicy
+	queue    chan error
+	closing  chan struct{}
+	dropped  atomic.Uint64
+	done     chan struct{}
+	once     sync.Once
+	mu       sync.RWMutex
+}
+
+// Create dispatcher passing errors to next and start its workers.
+// Register its Handle method as callback, e.g. RegisterCallback(NewAsyncDispatcher(fn, opts).Handle).
+func NewAsyncDispatcher(next Callback, opts AsyncOptions) *AsyncDispatcher {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultQueueSize
+	}
+
+	if opts.Workers <= 0 {
+		opts.Workers = 1
+	}
+
+	d := &AsyncDispatcher{
+		next:     next,
+		overflow: opts.Overflow,
+		queue:    make(chan error, opts.QueueSize),
+		closing:  make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(opts.Workers)
+	for i := 0; i < opts.Workers; i++ {
+		go func() {
+			defer wg.Done()
+			for err := range d.queue {
+				d.next(err)
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(d.done)
+	}()
+
+	return d
+}
+
+// Queue error for wrapped callback according to overflow policy.
+// Errors handled after Shutdown, or waiting for room when it is called, are dropped.
+func (d *AsyncDispatcher) Handle(err error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	select {
+	case <-d.closing:
+		d.dropped.Add(1)
+		return
+
+	default:
+	}
+
+	switch d.overflow {
+	case OverflowDropNewest:
+		select {
+		case d.queue <- err:
+		default:
+			d.dropped.Add(1)
+		}
+
+	case OverflowDropOldest:
+		for {
+			select {
+			case d.queue <- err:
+				return
+
+			default:
+			}
+
+			select {
+			case <-d.queue:
+				d.dropped.Add(1)
+
+			default:
+			}
+		}
+
+	default:
+		select {
+		case d.queue <- err:
+		case <-d.closing:
+			d.dropped.Add(1)
+		}
+
+	}
+}
+
+// Retrieve number of dropped errors.
+func (d *AsyncDispatcher) Dropped() uint64 {
+	return d.dropped.Load()
+}
+
+// Stop accepting errors and wait until queued ones are passed to wrapped callback.
+// Return context error if context is done first; workers keep draining in background.
+// Safe to call multiple times.
+func (d *AsyncDispatcher) Shutdown(ctx context.Context) error {
+	d.once.Do(func() {
+		close(d.closing)
+
+		// queue is closed once pending calls of Handle return
+		go func() {
+			d.mu.Lock()
+			close(d.queue)
+			d.mu.Unlock()
+		}()
+	})
+
+	select {
+	case <-d.done:
+		return nil
+
+	case <-ctx.Done():
+		return ctx.Err()
+
+	}
+}
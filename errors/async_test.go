@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAsyncDispatcher(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		opts     AsyncOptions
+		want     []string
+		dropped  uint64
+		blocking bool
+	}{
+		{"test#1", AsyncOptions{QueueSize: 2}, []string{"0", "1", "2", "3", "4"}, 0, true},
+		{"test#2", AsyncOptions{QueueSize: 2, Overflow: OverflowDropNewest}, []string{"0", "1", "2"}, 2, false},
+		{"test#3", AsyncOptions{QueueSize: 2, Overflow: OverflowDropOldest}, []string{"0", "3", "4"}, 2, false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			started, release := make(chan struct{}), make(chan struct{})
+			var once sync.Once
+
+			d := NewAsyncDispatcher(func(err error) {
+				once.Do(func() { close(started); <-release })
+				got = append(got, err.Error())
+			}, tt.opts)
+
+			d.Handle(fmt.Errorf("0"))
+			<-started
+
+			handled := make(chan struct{})
+			go func() {
+				defer close(handled)
+				for i := 1; i < 5; i++ {
+					d.Handle(fmt.Errorf("%d", i))
+				}
+			}()
+
+			select {
+			case <-handled:
+				if tt.blocking {
+					t.Errorf(`Handle() failed: did not block on full queue`)
+				}
+
+			case <-time.After(50 * time.Millisecond):
+				if !tt.blocking {
+					t.Errorf(`Handle() failed: blocked on full queue`)
+				}
+
+			}
+
+			close(release)
+			<-handled
+			if err := d.Shutdown(context.Background()); err != nil {
+				t.Fatalf(`Shutdown() failed: %v`, err)
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) || d.Dropped() != tt.dropped {
+				t.Errorf(`AsyncDispatcher failed: got: %v (dropped %d), want: %v (dropped %d)`, got, d.Dropped(), tt.want, tt.dropped)
+			}
+
+			d.Handle(fmt.Errorf("late"))
+			if d.Dropped() != tt.dropped+1 {
+				t.Errorf(`Handle() after Shutdown() failed: got dropped: %d`, d.Dropped())
+			}
+		})
+	}
+}
+
+func TestAsyncDispatcherShutdownTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	d := NewAsyncDispatcher(func(error) { <-release }, AsyncOptions{Workers: 2})
+	d.Handle(fmt.Errorf("slow"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := d.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Errorf(`Shutdown() failed: got: %v, want: %v`, err, context.DeadlineExceeded)
+	}
+}
+
+func TestAsyncDispatcherShutdownBlocked(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	d := NewAsyncDispatcher(func(error) { <-release }, AsyncOptions{QueueSize: 1})
+	for i := 0; i < 3; i++ {
+		go d.Handle(fmt.Errorf("blocked %d", i))
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if err := d.Shutdown(ctx); err != context.DeadlineExceeded || time.Since(start) > time.Second {
+		t.Errorf(`Shutdown() failed: got: %v after %v, want: %v`, err, time.Since(start), context.DeadlineExceeded)
+	}
+}
@@ -1,37 +1,133 @@
 package errors
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 )
 
-// Storage for callback function.
-var callback = (&defaultCallback{}).reset()
-
 // Store callback function
 type defaultCallback struct {
 	fn Callback
 }
 
 // Reset callback function to fmt.Fprintln(os.Stderr, err).
+// Fields and stack trace are included, see formatError.
 // Used for initialization as well.
 func (fn *defaultCallback) reset() *defaultCallback {
 	fn.fn = func(err error) {
-		_, _ = fmt.Fprintln(os.Stderr, err)
+		_, _ = fmt.Fprintln(os.Stderr, formatError(err))
 	}
 
 	return fn
 }
 
+// Format error message.
+// Fields of the error (see Fields) are appended as key=value pairs,
+// stack trace (see CaptureStack) is appended like with fmt.Sprintf("%+v", err).
+func formatError(err error) string {
+	var b strings.Builder
+	b.WriteString(err.Error())
+	if fields := Fields(err); len(fields) > 0 {
+		b.WriteString(" " + formatFields(fields))
+	}
+
+	var st *StackError
+	if errors.As(err, &st) {
+		writeTrace(&b, st.Frames())
+	}
+
+	return b.String()
+}
+
 // Callback function to handle error.
 type Callback func(error)
 
-// Reset callback function to fmt.Fprintln(os.Stderr, err).
+// Reset callback function of default scope to fmt.Fprintln(os.Stderr, err).
 func RestoreCallback() {
-	callback.reset()
+	defaultScope.RestoreCallback()
 }
 
-// Register custom callback to handle error.
+// Register custom callback to handle error in default scope.
 func RegisterCallback(fn Callback) {
-	callback.fn = fn
+	defaultScope.RegisterCallback(fn)
+}
+
+// Filter selecting errors passed to callback.
+type Filter func(error) bool
+
+// Select errors matching target (see Matches), which may be a Matcher.
+func FilterIs(target error) Filter {
+	return func(err error) bool { return Matches(err, target) }
+}
+
+// Select errors convertible to T (errors.As).
+func FilterAs[T error]() Filter {
+	return func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}
+}
+
+// Callback registered alongside the primary one.
+type registration struct {
+	id       uint64
+	fn       EventCallback
+	filter   Filter
+	priority int
+	stop     bool
+	ignored  bool
+	events   bool
+}
+
+// Option for callback registration.
+type CallbackOption func(*registration)
+
+// Pass only errors selected by filter to callback.
+func WithFilter(filter Filter) CallbackOption {
+	return func(r *registration) { r.filter = filter }
+}
+
+// Set priority of callback. Callbacks with higher priority run first.
+func WithPriority(priority int) CallbackOption {
+	return func(r *registration) { r.priority = priority }
+}
+
+// Prevent callbacks with lower priority (including the primary one) from running
+// once the callback has handled an error.
+func StopPropagation() CallbackOption {
+	return func(r *registration) { r.stop = true }
+}
+
+// Pass ignored errors to callback as well (see Event.Ignored).
+func IncludeIgnored() CallbackOption {
+	return func(r *registration) { r.ignored = true }
+}
+
+// Function notified of every handled error, including ignored ones.
+type Observer func(Record)
+
+// Observer registered in scope.
+type observation struct {
+	id uint64
+	fn Observer
+}
+
+// Handle of registered callback or observer.
+type CallbackHandle struct {
+	scope *Scope
+	id    uint64
+}
+
+// Remove callback or observer from its scope. Safe to call multiple times.
+func (h CallbackHandle) Unregister() {
+	if h.scope != nil {
+		h.scope.removeCallback(h.id)
+	}
+}
+
+// Add callback to default scope, running before the primary one.
+func AddCallback(fn Callback, opts ...CallbackOption) CallbackHandle {
+	return defaultScope.AddCallback(fn, opts...)
 }
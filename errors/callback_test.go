@@ -0,0 +1,91 @@
+package errors
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestAddCallback(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"test#1", os.ErrPermission, "audit:" + os.ErrPermission.Error() + ";"},
+		{"test#2", fmt.Errorf("wrapped: %w", os.ErrPermission), "audit:wrapped: " + os.ErrPermission.Error() + ";"},
+		{"test#3", &fs.PathError{Op: "open", Path: "file", Err: os.ErrNotExist}, "path:open file: file does not exist;primary:open file: file does not exist;"},
+		{"test#4", os.ErrClosed, "primary:" + os.ErrClosed.Error() + ";"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer strings.Builder
+			record := func(prefix string) Callback {
+				return func(err error) { buffer.WriteString(prefix + ":" + err.Error() + ";") }
+			}
+
+			scope := NewScope()
+			scope.RegisterCallback(record("primary"))
+			scope.AddCallback(record("path"), WithFilter(FilterAs[*fs.PathError]()))
+			scope.AddCallback(record("audit"), WithFilter(FilterIs(os.ErrPermission)), WithPriority(10), StopPropagation())
+
+			scope.Except(tt.err)
+			if got := buffer.String(); got != tt.want {
+				t.Errorf(`Except(%v) failed: got: %q, want: %q`, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallbackHandleUnregister(t *testing.T) {
+	var calls []string
+	scope := NewScope()
+	scope.RegisterCallback(func(error) { calls = append(calls, "primary") })
+	low := scope.AddCallback(func(error) { calls = append(calls, "low") }, WithPriority(-1))
+	scope.AddCallback(func(error) { calls = append(calls, "high") }, WithPriority(1))
+
+	scope.Except(os.ErrExist)
+	low.Unregister()
+	low.Unregister()
+	scope.Except(os.ErrExist)
+
+	if got, want := strings.Join(calls, ","), "high,low,primary,high,primary"; got != want {
+		t.Errorf(`callbacks failed: got: %q, want: %q`, got, want)
+	}
+}
+
+func TestAddObserver(t *testing.T) {
+	var records []Record
+	scope := NewScope()
+	scope.RegisterCallback(func(error) {})
+	handle := scope.AddObserver(func(r Record) { records = append(records, r) })
+
+	scope.Except(os.ErrExist)
+	scope.Except(os.ErrClosed, os.ErrClosed)
+	handle.Unregister()
+	scope.Except(os.ErrExist)
+
+	if len(records) != 2 || records[0].Err != os.ErrExist || records[0].Ignored || records[1].Err != os.ErrClosed || !records[1].Ignored {
+		t.Errorf(`AddObserver() failed: got: %+v`, records)
+	}
+}
+
+func TestFilterIs(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"test#1", fmt.Errorf("wrapped: %w", os.ErrExist), os.ErrExist, true},
+		{"test#2", &fs.PathError{Op: "open", Path: "file", Err: os.ErrNotExist}, MatchAs[*fs.PathError](), true},
+		{"test#3", os.ErrClosed, MatchAs[*fs.PathError](), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FilterIs(tt.target)(tt.err); got != tt.want {
+				t.Errorf(`FilterIs(%v)(%v) failed: got: %t, want: %t`, tt.target, tt.err, got, tt.want)
+			}
+		})
+	}
+}
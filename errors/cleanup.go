@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"errors"
+	"io"
+)
+
+// Close closer and handle its error like Except.
+// Unless ignored, the error is joined into errp (errors.Join).
+// Meant to be deferred with named return, e.g. defer Close(&err, file, os.ErrClosed).
+func Close(errp *error, closer io.Closer, ignore ...error) {
+	defaultScope.Defer(errp, closer.Close, ignore...)
+}
+
+// Call fn and handle its error like Except.
+// Unless ignored, the error is joined into errp (errors.Join).
+// Meant to be deferred with named return, e.g. defer Defer(&err, tx.Rollback).
+func Defer(errp *error, fn func() error, ignore ...error) {
+	defaultScope.Defer(errp, fn, ignore...)
+}
+
+// Close closer and handle its error like Except.
+// Unless ignored, the error is joined into errp (errors.Join).
+func (s *Scope) Close(errp *error, closer io.Closer, ignore ...error) {
+	s.Defer(errp, closer.Close, ignore...)
+}
+
+// Call fn and handle its error like Except.
+// Unless ignored, the error is joined into errp (errors.Join).
+func (s *Scope) Defer(errp *error, fn func() error, ignore ...error) {
+	err := s.handle(fn(), ignore)
+	if err == nil || errp == nil {
+		s.fail(err)
+		return
+	}
+
+	if *errp == nil {
+		*errp = err
+	} else {
+		*errp = errors.Join(*errp, err)
+	}
+
+	s.fail(err)
+}
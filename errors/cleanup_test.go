@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+)
+
+// Closer returning predefined error.
+type closerFunc func() error
+
+func (fn closerFunc) Close() error { return fn() }
+
+func TestClose(t *testing.T) {
+	type args struct {
+		err      error
+		closeErr error
+		ignore   []error
+	}
+	for _, tt := range []struct {
+		name     string
+		args     args
+		want     []error
+		reported string
+	}{
+		{"test#1", args{nil, nil, nil}, nil, ""},
+		{"test#2", args{nil, os.ErrExist, nil}, []error{os.ErrExist}, os.ErrExist.Error()},
+		{"test#3", args{os.ErrNotExist, os.ErrExist, nil}, []error{os.ErrNotExist, os.ErrExist}, os.ErrExist.Error()},
+		{"test#4", args{os.ErrNotExist, os.ErrClosed, []error{os.ErrClosed}}, []error{os.ErrNotExist}, ""},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := bytes.NewBuffer(nil)
+			scope := NewScope()
+			scope.RegisterCallback(func(err error) { buffer.WriteString(err.Error()) })
+
+			got := func() (err error) {
+				defer scope.Close(&err, closerFunc(func() error { return tt.args.closeErr }), tt.args.ignore...)
+				return tt.args.err
+			}()
+
+			if (got == nil) != (len(tt.want) == 0) {
+				t.Errorf(`Close() failed: got: %v, want: %v`, got, tt.want)
+			}
+
+			for _, want := range tt.want {
+				if !errors.Is(got, want) {
+					t.Errorf(`Close() failed: got: %v, want: %v`, got, want)
+				}
+			}
+
+			if reported := buffer.String(); reported != tt.reported {
+				t.Errorf(`Close() failed: got reported: %q, want: %q`, reported, tt.reported)
+			}
+		})
+	}
+}
+
+func TestDefer(t *testing.T) {
+	RegisterCallback(func(error) {})
+	defer RestoreCallback()
+
+	got := func() (err error) {
+		defer Defer(&err, func() error { return os.ErrExist })
+		return nil
+	}()
+
+	if got != os.ErrExist || !LastErrorWas(os.ErrExist) {
+		t.Errorf(`Defer() failed: got: %v, want: %v`, got, os.ErrExist)
+	}
+}
@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"sync"
+	"time"
+)
+
+// Source of time.
+type Clock interface {
+	// Return current time.
+	Now() time.Time
+
+	// Return channel receiving current time after duration d.
+	After(d time.Duration) <-chan time.Time
+}
+
+// Clock backed by package time.
+var SystemClock Clock = systemClock{}
+
+// Clock backed by package time.
+type systemClock struct{}
+
+// Implement Clock.
+func (systemClock) Now() time.Time { return time.Now() }
+
+// Implement Clock.
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Clock advancing only on demand (thread-safe).
+// Waiting through After advances the clock immediately.
+type FakeClock struct {
+	now time.Time
+	sync.Mutex
+}
+
+// Create fake clock starting at now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Implement Clock.
+func (c *FakeClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.now
+}
+
+// Implement Clock. Advance clock by d and return ready channel.
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- c.Advance(d)
+	return ch
+}
+
+// Advance clock by d and return new time.
+func (c *FakeClock) Advance(d time.Duration) time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	c.now = c.now.Add(d)
+	return c.now
+}
+
+// Clock advancing only on demand (thread-safe).
+// Unlike FakeClock, waiting through After lasts until the clock is advanced past the deadline,
+// which suits background timers, e.g. of Suppressor.
+type ManualClock struct {
+	now    time.Time
+	timers []manualTimer
+	sync.Mutex
+}
+
+// Pending wait of ManualClock.
+type manualTimer struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// Create manual clock starting at now.
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Implement Clock.
+func (c *ManualClock) Now() time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	return c.now
+}
+
+// Implement Clock. Return channel receiving time once clock is advanced by d.
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+
+	c.timers = append(c.timers, manualTimer{deadline: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance clock by d, fire expired waits and return new time.
+func (c *ManualClock) Advance(d time.Duration) time.Time {
+	c.Lock()
+	defer c.Unlock()
+
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.deadline.After(c.now) {
+			pending = append(pending, timer)
+			continue
+		}
+
+		timer.ch <- c.now
+	}
+
+	c.timers = pending
+	return c.now
+}
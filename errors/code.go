@@ -0,0 +1,243 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Registry of coded errors.
+var codes = &codeRegistry{defs: make(map[Code]*CodedError)}
+
+// Stable, machine-readable error code.
+type Code string
+
+// Canonical error code (gRPC-style).
+type Canonical uint32
+
+const (
+	CanonicalOK Canonical = iota
+	CanonicalCanceled
+	CanonicalUnknown
+	CanonicalInvalidArgument
+	CanonicalDeadlineExceeded
+	CanonicalNotFound
+	CanonicalAlreadyExists
+	CanonicalPermissionDenied
+	CanonicalResourceExhausted
+	CanonicalFailedPrecondition
+	CanonicalAborted
+	CanonicalOutOfRange
+	CanonicalUnimplemented
+	CanonicalInternal
+	CanonicalUnavailable
+	CanonicalDataLoss
+	CanonicalUnauthenticated
+)
+
+// Names and default HTTP statuses of canonical codes.
+var canonicals = [...]struct {
+	name   string
+	status int
+}{
+	CanonicalOK:                 {"OK", 200},
+	CanonicalCanceled:           {"Canceled", 499},
+	CanonicalUnknown:            {"Unknown", 500},
+	CanonicalInvalidArgument:    {"InvalidArgument", 400},
+	CanonicalDeadlineExceeded:   {"DeadlineExceeded", 504},
+	CanonicalNotFound:           {"NotFound", 404},
+	CanonicalAlreadyExists:      {"AlreadyExists", 409},
+	CanonicalPermissionDenied:   {"PermissionDenied", 403},
+	CanonicalResourceExhausted:  {"ResourceExhausted", 429},
+	CanonicalFailedPrecondition: {"FailedPrecondition", 400},
+	CanonicalAborted:            {"Aborted", 409},
+	CanonicalOutOfRange:         {"OutOfRange", 400},
+	CanonicalUnimplemented:      {"Unimplemented", 501},
+	CanonicalInternal:           {"Internal", 500},
+	CanonicalUnavailable:        {"Unavailable", 503},
+	CanonicalDataLoss:           {"DataLoss", 500},
+	CanonicalUnauthenticated:    {"Unauthenticated", 401},
+}
+
+func (c Canonical) String() string {
+	if int(c) < len(canonicals) {
+		return canonicals[c].name
+	}
+
+	return "Unknown"
+}
+
+// Return default HTTP status of canonical code.
+func (c Canonical) Status() int {
+	if int(c) < len(canonicals) {
+		return canonicals[c].status
+	}
+
+	return CanonicalUnknown.Status()
+}
+
+// Error with stable code.
+// Registered instances act as sentinels, instances created by New and Wrap match them (errors.Is).
+type CodedError struct {
+	// Stable code, unique within the registry.
+	Code Code
+
+	// Message, used as fmt template by New and Wrap.
+	Message string
+
+	// HTTP status, defaults to the one of canonical code.
+	Status int
+
+	// Canonical code (gRPC-style).
+	Canonical Canonical
+
+	// Whether the operation may be retried.
+	Retryable bool
+
+	sentinel *CodedError
+	cause    error
+}
+
+// Return message.
+func (e *CodedError) Error() string { return e.Message }
+
+// Return wrapped cause.
+func (e *CodedError) Unwrap() error { return e.cause }
+
+// Report whether target is the sentinel of this error.
+func (e *CodedError) Is(target error) bool {
+	t, ok := target.(*CodedError)
+	return ok && e.sentinel != nil && t == e.sentinel
+}
+
+// Return HTTP status, defaulting to the one of canonical code.
+func (e *CodedError) HTTPStatus() int {
+	if e.Status != 0 {
+		return e.Status
+	}
+
+	return e.Canonical.Status()
+}
+
+// Create instance with message formatted using args.
+func (e *CodedError) New(args ...any) *CodedError {
+	return e.Wrap(nil, args...)
+}
+
+// Create instance wrapping cause with message formatted using args.
+func (e *CodedError) Wrap(cause error, args ...any) *CodedError {
+	instance := *e
+	if instance.sentinel == nil {
+		instance.sentinel = e
+	}
+
+	if len(args) > 0 {
+		instance.Message = fmt.Sprintf(e.Message, args...)
+	}
+
+	instance.cause = cause
+	return &instance
+}
+
+// Registry of coded errors (thread-safe).
+type codeRegistry struct {
+	defs map[Code]*CodedError
+	sync.RWMutex
+}
+
+// Register coded error as sentinel and return it.
+// Panic if code is empty or already registered.
+// Meant to be used in package-level var declarations.
+func Register(def CodedError) *CodedError {
+	if def.Code == "" {
+		panic("errors: register of empty code")
+	}
+
+	codes.Lock()
+	defer codes.Unlock()
+
+	if _, ok := codes.defs[def.Code]; ok {
+		panic(fmt.Sprintf("errors: duplicate code: %q", def.Code))
+	}
+
+	def.sentinel, def.cause = nil, nil
+	codes.defs[def.Code] = &def
+	return &def
+}
+
+// Retrieve registered sentinel by code.
+func Lookup(code Code) (*CodedError, bool) {
+	codes.RLock()
+	defer codes.RUnlock()
+
+	def, ok := codes.defs[code]
+	return def, ok
+}
+
+// Retrieve registered codes in ascending order.
+func Codes() []Code {
+	codes.RLock()
+	defer codes.RUnlock()
+
+	out := make([]Code, 0, len(codes.defs))
+	for code := range codes.defs {
+		out = append(out, code)
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+// Retrieve first coded error in chain.
+func codedOf(err error) (*CodedError, bool) {
+	var coded *CodedError
+	if errors.As(err, &coded) {
+		return coded, true
+	}
+
+	return nil, false
+}
+
+// Retrieve code of first coded error in chain, or empty code.
+func CodeOf(err error) Code {
+	if coded, ok := codedOf(err); ok {
+		return coded.Code
+	}
+
+	return ""
+}
+
+// Retrieve HTTP status of first coded error in chain.
+// Return 200 for nil and 500 for errors without code.
+func StatusOf(err error) int {
+	if err == nil {
+		return CanonicalOK.Status()
+	}
+
+	if coded, ok := codedOf(err); ok {
+		return coded.HTTPStatus()
+	}
+
+	return CanonicalUnknown.Status()
+}
+
+// Retrieve canonical code of first coded error in chain.
+// Return CanonicalOK for nil and CanonicalUnknown for errors without code.
+func CanonicalOf(err error) Canonical {
+	if err == nil {
+		return CanonicalOK
+	}
+
+	if coded, ok := codedOf(err); ok {
+		return coded.Canonical
+	}
+
+	return CanonicalUnknown
+}
+
+// Report whether first coded error in chain is retryable.
+func IsRetryable(err error) bool {
+	coded, ok := codedOf(err)
+	return ok && coded.Retryable
+}
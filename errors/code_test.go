@@ -0,0 +1,116 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+)
+
+var (
+	errTestNotFound = Register(CodedError{
+		Code:      "test.not_found",
+		Message:   "item %q not found",
+		Canonical: CanonicalNotFound,
+	})
+	errTestUnavailable = Register(CodedError{
+		Code:      "test.unavailable",
+		Message:   "service unavailable",
+		Status:    502,
+		Canonical: CanonicalUnavailable,
+		Retryable: true,
+	})
+)
+
+func TestCodedError(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		err       error
+		sentinel  error
+		message   string
+		code      Code
+		status    int
+		canonical Canonical
+		retryable bool
+	}{
+		{"test#1", nil, nil, "", "", 200, CanonicalOK, false},
+		{"test#2", os.ErrExist, os.ErrExist, os.ErrExist.Error(), "", 500, CanonicalUnknown, false},
+		{"test#3", errTestNotFound.New("x"), errTestNotFound, `item "x" not found`, "test.not_found", 404, CanonicalNotFound, false},
+		{"test#4", fmt.Errorf("get: %w", errTestNotFound.New("x")), errTestNotFound, `get: item "x" not found`, "test.not_found", 404, CanonicalNotFound, false},
+		{"test#5", errTestUnavailable.Wrap(os.ErrDeadlineExceeded), os.ErrDeadlineExceeded, "service unavailable", "test.unavailable", 502, CanonicalUnavailable, true},
+		{"test#6", errTestUnavailable.Wrap(os.ErrDeadlineExceeded), errTestUnavailable, "service unavailable", "test.unavailable", 502, CanonicalUnavailable, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.err != nil && (!errors.Is(tt.err, tt.sentinel) || tt.err.Error() != tt.message) {
+				t.Errorf(`%v failed: got: %q, want: %q matching %v`, tt.err, tt.err, tt.message, tt.sentinel)
+			}
+
+			if got := CodeOf(tt.err); got != tt.code {
+				t.Errorf(`CodeOf(%v) failed: got: %q, want: %q`, tt.err, got, tt.code)
+			}
+
+			if got := StatusOf(tt.err); got != tt.status {
+				t.Errorf(`StatusOf(%v) failed: got: %d, want: %d`, tt.err, got, tt.status)
+			}
+
+			if got := CanonicalOf(tt.err); got != tt.canonical {
+				t.Errorf(`CanonicalOf(%v) failed: got: %v, want: %v`, tt.err, got, tt.canonical)
+			}
+
+			if got := IsRetryable(tt.err); got != tt.retryable {
+				t.Errorf(`IsRetryable(%v) failed: got: %t, want: %t`, tt.err, got, tt.retryable)
+			}
+		})
+	}
+
+	if errors.Is(errTestNotFound.New("x"), errTestUnavailable) {
+		t.Errorf(`errors.Is() failed: instance matches foreign sentinel`)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if def, ok := Lookup("test.not_found"); !ok || def != errTestNotFound {
+		t.Errorf(`Lookup() failed: got: %v, %t`, def, ok)
+	}
+
+	if !slices.Contains(Codes(), "test.unavailable") {
+		t.Errorf(`Codes() failed: got: %v`, Codes())
+	}
+
+	for _, tt := range []struct {
+		name string
+		def  CodedError
+	}{
+		{"test#1", CodedError{}},
+		{"test#2", CodedError{Code: "test.not_found"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf(`Register(%v) failed: got no panic`, tt.def)
+				}
+			}()
+
+			_ = Register(tt.def)
+		})
+	}
+}
+
+func TestExceptCode(t *testing.T) {
+	var got Code
+	scope := NewScope().Ignore(MatchCode("test.unavailable"))
+	scope.RegisterCallback(func(err error) { got = CodeOf(err) })
+
+	scope.Except(errTestUnavailable.New())
+	scope.Except(fmt.Errorf("get: %w", errTestNotFound.New("x")))
+
+	if got != "test.not_found" {
+		t.Errorf(`Except() failed: got code: %q, want: %q`, got, "test.not_found")
+	}
+
+	history := scope.History()
+	if len(history) != 2 || history[0].Code != "test.unavailable" || !history[0].Ignored || history[1].Code != "test.not_found" {
+		t.Errorf(`History() failed: got: %+v`, history)
+	}
+}
@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"errors"
+	"sync"
+)
+
+// Collector of errors, accumulating them instead of reporting immediately (thread-safe).
+// Implements Handler, so it can be used with ExceptFnIn and alike.
+// The zero value is usable and flushes into default scope.
+type Collector struct {
+	scope   *Scope
+	errs    []error
+	ignored []error
+	sync.Mutex
+}
+
+// Create collector flushing into default scope.
+func NewCollector() *Collector {
+	return defaultScope.Collector()
+}
+
+// Create collector flushing into the scope.
+// Ignore list of the scope applies to collected errors.
+func (s *Scope) Collector() *Collector {
+	return &Collector{scope: s}
+}
+
+// Collect error if not nil.
+// Errors among ignored ones are tracked separately.
+func (c *Collector) Except(err error, ignore ...error) {
+	if err == nil {
+		return
+	}
+
+	scoped := c.target().state.Load().ignore
+
+	c.Lock()
+	defer c.Unlock()
+
+	if isIgnored(err, scoped, ignore) {
+		c.ignored = append(c.ignored, err)
+		return
+	}
+
+	c.errs = append(c.errs, err)
+}
+
+// Retrieve collected errors in order of occurrence.
+func (c *Collector) Errors() []error {
+	c.Lock()
+	defer c.Unlock()
+
+	return append([]error(nil), c.errs...)
+}
+
+// Retrieve ignored errors in order of occurrence.
+func (c *Collector) Ignored() []error {
+	c.Lock()
+	defer c.Unlock()
+
+	return append([]error(nil), c.ignored...)
+}
+
+// Join collected errors (errors.Join).
+// Return nil if there are none.
+func (c *Collector) Err() error {
+	c.Lock()
+	defer c.Unlock()
+
+	return errors.Join(c.errs...)
+}
+
+// Report joined errors to the scope and clear them.
+// Ignored errors are kept for auditing.
+// Return reported error.
+func (c *Collector) Flush() error {
+	c.Lock()
+	err := errors.Join(c.errs...)
+	c.errs = nil
+	c.Unlock()
+
+	c.target().Except(err)
+	return err
+}
+
+// Retrieve scope to flush into.
+func (c *Collector) target() *Scope {
+	if c.scope == nil {
+		return defaultScope
+	}
+
+	return c.scope
+}
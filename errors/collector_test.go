@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestCollector(t *testing.T) {
+	var reported []error
+	scope := NewScope().Ignore(os.ErrClosed)
+	scope.RegisterCallback(func(err error) { reported = append(reported, err) })
+
+	c := scope.Collector()
+	c.Except(nil)
+	c.Except(os.ErrExist)
+	_ = ExceptFnIn(c, W(0, os.ErrNotExist))
+	_, _ = ExceptFn2In(c, W2(0, 0, os.ErrPermission), os.ErrPermission)
+	c.Except(fmt.Errorf("wrapped: %w", os.ErrClosed))
+
+	if got := c.Errors(); len(got) != 2 || got[0] != os.ErrExist || got[1] != os.ErrNotExist {
+		t.Errorf(`Errors() failed: got: %v, want: [%v %v]`, got, os.ErrExist, os.ErrNotExist)
+	}
+
+	if got := c.Ignored(); len(got) != 2 || !errors.Is(got[0], os.ErrPermission) || !errors.Is(got[1], os.ErrClosed) {
+		t.Errorf(`Ignored() failed: got: %v, want: [%v %v]`, got, os.ErrPermission, os.ErrClosed)
+	}
+
+	if len(reported) != 0 {
+		t.Errorf(`Except() failed: reported immediately: %v`, reported)
+	}
+
+	err := c.Flush()
+	if !errors.Is(err, os.ErrExist) || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf(`Flush() failed: got: %v`, err)
+	}
+
+	if len(reported) != 1 || reported[0] != err || !scope.LastErrorWas(os.ErrNotExist) {
+		t.Errorf(`Flush() failed: got reported: %v, want: [%v]`, reported, err)
+	}
+
+	if c.Err() != nil || len(c.Ignored()) != 2 {
+		t.Errorf(`Flush() failed: got: %v, ignored: %v`, c.Err(), c.Ignored())
+	}
+
+	if err := c.Flush(); err != nil || len(reported) != 1 {
+		t.Errorf(`Flush() of empty collector failed: got: %v, reported: %v`, err, reported)
+	}
+}
+
+func TestCollectorConcurrent(t *testing.T) {
+	c := NewScope().Collector()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c.Except(fmt.Errorf("%d", i))
+		}(i)
+	}
+
+	wg.Wait()
+	if got := len(c.Errors()); got != 100 {
+		t.Errorf(`Except() failed: got %d errors, want 100`, got)
+	}
+}
+
+func TestCollectorZero(t *testing.T) {
+	RegisterCallback(func(error) {})
+	defer RestoreCallback()
+
+	var c Collector
+	c.Except(os.ErrExist)
+	if err := c.Flush(); !errors.Is(err, os.ErrExist) || !LastErrorWas(os.ErrExist) {
+		t.Errorf(`Flush() failed: got: %v`, err)
+	}
+}
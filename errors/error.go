@@ -1,42 +1,34 @@
 package errors
 
 import (
-	"errors"
-	"sync"
+	"sync/atomic"
 )
 
-// Storer for last occurred error.
-var lastError = &errorKeeper{}
-
-// Store last error (thread-safe).
+// Store last error (thread-safe, lock-free).
 type errorKeeper struct {
-	err error
-	sync.RWMutex
+	err atomic.Pointer[error]
 }
 
 // Retrieve last error
 func (k *errorKeeper) read() error {
-	for !k.TryRLock() {
+	if err := k.err.Load(); err != nil {
+		return *err
 	}
-	defer k.RUnlock()
 
-	return k.err
+	return nil
 }
 
 // Store error.
 func (k *errorKeeper) store(err error) {
-	for !k.TryLock() {
-	}
-	k.err = err
-	k.Unlock()
+	k.err.Store(&err)
 }
 
-// Retrieve last error.
+// Retrieve last error of default scope.
 func LastError() error {
-	return lastError.read()
+	return defaultScope.LastError()
 }
 
-// Check if last error was of this kind.
+// Check if last error of default scope was of this kind.
 func LastErrorWas(err error) bool {
-	return errors.Is(lastError.read(), err)
+	return defaultScope.LastErrorWas(err)
 }
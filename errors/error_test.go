@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"os"
+	"sync"
+	"testing"
+)
+
+// Former spinning implementation of errorKeeper, kept as baseline for benchmarks.
+type spinKeeper struct {
+	err error
+	sync.RWMutex
+}
+
+func (k *spinKeeper) read() error {
+	for !k.TryRLock() {
+	}
+	defer k.RUnlock()
+
+	return k.err
+}
+
+func (k *spinKeeper) store(err error) {
+	for !k.TryLock() {
+	}
+	k.err = err
+	k.Unlock()
+}
+
+func TestErrorKeeper(t *testing.T) {
+	k := &errorKeeper{}
+	if err := k.read(); err != nil {
+		t.Errorf(`read() failed: got: %v, want: nil`, err)
+	}
+
+	k.store(os.ErrExist)
+	if err := k.read(); err != os.ErrExist {
+		t.Errorf(`read() failed: got: %v, want: %v`, err, os.ErrExist)
+	}
+}
+
+func TestExceptNilAllocs(t *testing.T) {
+	scope := NewScope()
+	fn := W[any](nil, nil)
+	if allocs := testing.AllocsPerRun(100, func() {
+		Except(nil)
+		scope.Except(nil, os.ErrExist)
+		_ = ExceptFnIn(scope, fn)
+	}); allocs != 0 {
+		t.Errorf(`Except(nil) failed: got %v allocations, want 0`, allocs)
+	}
+}
+
+func BenchmarkExceptNil(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			Except(nil)
+		}
+	})
+}
+
+func BenchmarkExcept(b *testing.B) {
+	scope := NewScope()
+	scope.RegisterCallback(func(error) {})
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			scope.Except(os.ErrExist)
+		}
+	})
+}
+
+func BenchmarkErrorKeeper(b *testing.B) {
+	atomicKeeper, spin := &errorKeeper{}, &spinKeeper{}
+	for _, bb := range []struct {
+		name  string
+		read  func() error
+		store func(error)
+	}{
+		{"atomic", atomicKeeper.read, atomicKeeper.store},
+		{"spin", spin.read, spin.store},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					if i%8 == 0 {
+						bb.store(os.ErrExist)
+						continue
+					}
+
+					_ = bb.read()
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkExceptEvent(b *testing.B) {
+	scope := NewScope()
+	scope.RegisterEventCallback(func(Event) {})
+
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			scope.Except(os.ErrExist)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+// Package errorstest provides helpers for asserting error handling in tests.
+package errorstest
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	supererrors "github.com/sarumaj/go-super/errors"
+)
+
+// Locks granting exclusive capture of scopes.
+var locks sync.Map
+
+// Recorder of errors handled by scope (thread-safe).
+type Recorder struct {
+	t        testing.TB
+	scope    *supererrors.Scope
+	reported []error
+	ignored  []error
+	sync.Mutex
+}
+
+// Capture errors handled by default scope until the test ends.
+// See CaptureScope.
+func Capture(t testing.TB) *Recorder {
+	t.Helper()
+	return CaptureScope(t, supererrors.DefaultScope())
+}
+
+// Capture errors handled by scope until the test ends.
+// Reported errors are recorded instead of being passed to the primary callback,
+// which is restored through t.Cleanup.
+// Captures of the same scope are exclusive, so parallel tests wait for each other.
+// Capturing the same scope twice within a test deadlocks.
+func CaptureScope(t testing.TB, scope *supererrors.Scope) *Recorder {
+	t.Helper()
+
+	lock, _ := locks.LoadOrStore(scope, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+
+	r := &Recorder{t: t, scope: scope}
+	previous := scope.Callback()
+	scope.RegisterCallback(func(error) {})
+	handle := scope.AddCallback(r.record, supererrors.WithPriority(math.MaxInt))
+	observer := scope.AddObserver(r.observe)
+
+	t.Cleanup(func() {
+		handle.Unregister()
+		observer.Unregister()
+		scope.RegisterEventCallback(previous)
+		lock.(*sync.Mutex).Unlock()
+	})
+
+	return r
+}
+
+// Record reported error.
+func (r *Recorder) record(err error) {
+	r.Lock()
+	r.reported = append(r.reported, err)
+	r.Unlock()
+}
+
+// Record ignored error.
+func (r *Recorder) observe(record supererrors.Record) {
+	if !record.Ignored {
+		return
+	}
+
+	r.Lock()
+	r.ignored = append(r.ignored, record.Err)
+	r.Unlock()
+}
+
+// Retrieve captured scope.
+func (r *Recorder) Scope() *supererrors.Scope { return r.scope }
+
+// Retrieve reported errors in order of occurrence.
+func (r *Recorder) Reported() []error {
+	r.Lock()
+	defer r.Unlock()
+
+	return append([]error(nil), r.reported...)
+}
+
+// Retrieve ignored errors in order of occurrence.
+func (r *Recorder) Ignored() []error {
+	r.Lock()
+	defer r.Unlock()
+
+	return append([]error(nil), r.ignored...)
+}
+
+// Assert that an error matching target was reported (see supererrors.Matches).
+func (r *Recorder) AssertReported(target error) bool {
+	r.t.Helper()
+
+	for _, err := range r.Reported() {
+		if supererrors.Matches(err, target) {
+			return true
+		}
+	}
+
+	r.t.Errorf("errorstest: no reported error matches %v, reported: %v", target, r.Reported())
+	return false
+}
+
+// Assert that no error was reported.
+func (r *Recorder) AssertNothingReported() bool {
+	r.t.Helper()
+
+	if reported := r.Reported(); len(reported) > 0 {
+		r.t.Errorf("errorstest: expected nothing reported, reported: %v", reported)
+		return false
+	}
+
+	return true
+}
+
+// Assert that an error matching target was ignored (see supererrors.Matches).
+func (r *Recorder) AssertIgnored(target error) bool {
+	r.t.Helper()
+
+	for _, err := range r.Ignored() {
+		if supererrors.Matches(err, target) {
+			return true
+		}
+	}
+
+	r.t.Errorf("errorstest: no ignored error matches %v, ignored: %v", target, r.Ignored())
+	return false
+}
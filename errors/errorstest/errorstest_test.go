@@ -0,0 +1,92 @@
+package errorstest
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	supererrors "github.com/sarumaj/go-super/errors"
+)
+
+// Test double recording failures instead of failing the test.
+type fakeT struct {
+	testing.TB
+	failures []string
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func TestCapture(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		run  func()
+		want func(*Recorder) bool
+	}{
+		{"test#1", func() {}, (*Recorder).AssertNothingReported},
+		{"test#2", func() { supererrors.Except(os.ErrExist) }, func(r *Recorder) bool { return r.AssertReported(os.ErrExist) }},
+		{"test#3", func() { supererrors.Except(os.ErrExist, os.ErrExist) }, func(r *Recorder) bool {
+			return r.AssertIgnored(os.ErrExist) && r.AssertNothingReported()
+		}},
+		{"test#4", func() { _ = supererrors.ExceptFn(supererrors.W(0, fmt.Errorf("wrapped: %w", os.ErrClosed))) }, func(r *Recorder) bool {
+			return r.AssertReported(os.ErrClosed)
+		}},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := Capture(t)
+			tt.run()
+			if !tt.want(r) {
+				t.Errorf(`%s failed: got reported: %v, ignored: %v`, tt.name, r.Reported(), r.Ignored())
+			}
+		})
+	}
+}
+
+func TestCaptureFailures(t *testing.T) {
+	scope := supererrors.NewScope()
+	previous := scope.Callback()
+
+	t.Run("capture", func(t *testing.T) {
+		f := &fakeT{TB: t}
+		r := CaptureScope(f, scope)
+		scope.Except(os.ErrExist)
+
+		for _, ok := range []bool{
+			r.AssertNothingReported(),
+			r.AssertReported(os.ErrClosed),
+			r.AssertIgnored(os.ErrExist),
+		} {
+			if ok {
+				t.Errorf(`assertion unexpectedly succeeded`)
+			}
+		}
+
+		if len(f.failures) != 3 {
+			t.Errorf(`got %d failures, want 3: %v`, len(f.failures), f.failures)
+		}
+	})
+
+	if got := scope.Callback(); fmt.Sprintf("%p", got) != fmt.Sprintf("%p", previous) {
+		t.Errorf(`Cleanup failed: callback not restored`)
+	}
+}
+
+func TestCaptureIgnoredBeyondHistory(t *testing.T) {
+	scope := supererrors.NewScope()
+	r := CaptureScope(t, scope)
+
+	scope.Except(os.ErrExist, os.ErrExist)
+	for i := 0; i < 2*supererrors.DefaultHistoryCapacity; i++ {
+		scope.Except(os.ErrClosed)
+	}
+
+	if !r.AssertIgnored(os.ErrExist) || len(r.Reported()) != 2*supererrors.DefaultHistoryCapacity {
+		t.Errorf(`Ignored() failed: got: %v`, r.Ignored())
+	}
+}
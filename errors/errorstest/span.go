@@ -0,0 +1,58 @@
+package errorstest
+
+import (
+	"context"
+	"sync"
+
+	supererrors "github.com/sarumaj/go-super/errors"
+)
+
+// Error recorded on span.
+type SpanEvent struct {
+	Err    error
+	Fields map[string]any
+}
+
+// In-memory span recorder (thread-safe).
+type Span struct {
+	events      []SpanEvent
+	status      supererrors.Canonical
+	description string
+	sync.Mutex
+}
+
+// Create span recorder and attach it to context.
+func WithSpan(ctx context.Context) (context.Context, *Span) {
+	s := &Span{}
+	return supererrors.WithSpanRecorder(ctx, s), s
+}
+
+// Implement supererrors.SpanRecorder.
+func (s *Span) RecordError(err error, fields map[string]any) {
+	s.Lock()
+	s.events = append(s.events, SpanEvent{Err: err, Fields: fields})
+	s.Unlock()
+}
+
+// Implement supererrors.SpanRecorder.
+func (s *Span) SetStatus(code supererrors.Canonical, description string) {
+	s.Lock()
+	s.status, s.description = code, description
+	s.Unlock()
+}
+
+// Retrieve recorded errors.
+func (s *Span) Events() []SpanEvent {
+	s.Lock()
+	defer s.Unlock()
+
+	return append([]SpanEvent(nil), s.events...)
+}
+
+// Retrieve status of span, CanonicalOK if not set.
+func (s *Span) Status() (supererrors.Canonical, string) {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.status, s.description
+}
@@ -0,0 +1,26 @@
+package errorstest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	supererrors "github.com/sarumaj/go-super/errors"
+)
+
+func TestWithSpan(t *testing.T) {
+	r := Capture(t)
+	ctx, span := WithSpan(context.Background())
+
+	supererrors.ExceptCtx(ctx, os.ErrExist, os.ErrExist)
+	supererrors.ExceptCtx(ctx, supererrors.With(os.ErrClosed, "id", 1))
+
+	events := span.Events()
+	if len(events) != 1 || !r.AssertReported(os.ErrClosed) || events[0].Fields["id"] != 1 {
+		t.Errorf(`ExceptCtx() failed: got: %+v`, events)
+	}
+
+	if code, description := span.Status(); code != supererrors.CanonicalUnknown || description != events[0].Err.Error() {
+		t.Errorf(`Status() failed: got: %v %q`, code, description)
+	}
+}
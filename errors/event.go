@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"bytes"
+	"errors"
+	"runtime"
+	"strconv"
+)
+
+// Occurrence of handled error, passed to EventCallback.
+type Event struct {
+	Record
+
+	// Call site of Except (or alike), outside of this package.
+	File     string
+	Line     int
+	Function string
+
+	// ID of goroutine which handled the error,
+	// parsed from header of its stack trace (see runtime.Stack).
+	Goroutine uint64
+
+	// Ignore list in effect, of the scope followed by the one of the call.
+	Ignore []error
+}
+
+// Callback function to handle error event.
+// Call site, goroutine and ignore list of events are resolved
+// only if an EventCallback is registered, since resolving them is costly.
+type EventCallback func(Event)
+
+// Adapt callback to handle error events.
+func adapt(fn Callback) EventCallback {
+	return func(e Event) { fn(e.Err) }
+}
+
+// Create event of handled error, ignore being the ignore list of the call.
+// Details are resolved only if any callback needs them.
+func (state *scopeState) newEvent(record Record, ignore []error) Event {
+	event := Event{Record: record}
+	if !state.wantsEvents() {
+		return event
+	}
+
+	event.Goroutine = goroutineID()
+	event.Ignore = append(append(make([]error, 0, len(state.ignore)+len(ignore)), state.ignore...), ignore...)
+
+	if frame, ok := callerFrame(record.Err); ok {
+		event.File, event.Line, event.Function = frame.File, frame.Line, frame.Function
+	}
+
+	return event
+}
+
+// Retrieve call site of error, taken from captured stack, if any,
+// or from the current one, skipping frames belonging to this package or the runtime.
+// Report false if there is no such frame, e.g. in goroutines started by Group.
+func callerFrame(err error) (runtime.Frame, bool) {
+	var st *StackError
+	if errors.As(err, &st) {
+		if frames := st.Frames(); len(frames) > 0 {
+			return frames[0], true
+		}
+	}
+
+	var pcs [maxStackDepth]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if isUserFrame(frame) {
+			return frame, frame.PC != 0
+		}
+
+		if !more {
+			return runtime.Frame{}, false
+		}
+	}
+}
+
+// Retrieve ID of current goroutine from header of its stack trace.
+func goroutineID() uint64 {
+	var buf [64]byte
+	fields := bytes.Fields(buf[:runtime.Stack(buf[:], false)])
+	if len(fields) < 2 {
+		return 0
+	}
+
+	id, _ := strconv.ParseUint(string(fields[1]), 10, 64)
+	return id
+}
+
+// Register custom callback to handle error events in default scope.
+func RegisterEventCallback(fn EventCallback) {
+	defaultScope.RegisterEventCallback(fn)
+}
+
+// Add callback of error events to default scope, running before the primary one.
+func AddEventCallback(fn EventCallback, opts ...CallbackOption) CallbackHandle {
+	return defaultScope.AddEventCallback(fn, opts...)
+}
@@ -0,0 +1,101 @@
+package errors
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestRegisterEventCallback(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		err     error
+		ignore  []error
+		ignored bool
+		calls   int
+	}{
+		{"test#1", os.ErrExist, nil, false, 1},
+		{"test#2", os.ErrClosed, []error{os.ErrClosed}, true, 0},
+		{"test#3", nil, nil, false, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var events []Event
+			scope := NewScope().Ignore(os.ErrDeadlineExceeded)
+			scope.RegisterEventCallback(func(e Event) { events = append(events, e) })
+
+			_, file, line, _ := runtime.Caller(0)
+			scope.Except(tt.err, tt.ignore...)
+
+			if len(events) != tt.calls {
+				t.Fatalf(`Except(%v) failed: got: %d events, want: %d`, tt.err, len(events), tt.calls)
+			}
+
+			for _, e := range events {
+				switch {
+				case e.Err != tt.err || e.Ignored != tt.ignored || e.Time.IsZero():
+					t.Errorf(`Except(%v) failed: got: %+v`, tt.err, e.Record)
+				case filepath.Base(e.File) != filepath.Base(file) || e.Line != line+1 || !strings.HasSuffix(e.Function, "TestRegisterEventCallback.func1"):
+					t.Errorf(`Except(%v) failed: got: %s:%d (%s), want: %s:%d`, tt.err, e.File, e.Line, e.Function, file, line+1)
+				case e.Goroutine == 0:
+					t.Errorf(`Except(%v) failed: got: goroutine %d`, tt.err, e.Goroutine)
+				case len(e.Ignore) != 1+len(tt.ignore) || e.Ignore[0] != os.ErrDeadlineExceeded:
+					t.Errorf(`Except(%v) failed: got: ignore %v`, tt.err, e.Ignore)
+				}
+			}
+		})
+	}
+}
+
+func TestAddEventCallbackIncludeIgnored(t *testing.T) {
+	var calls []string
+	scope := NewScope()
+	scope.RegisterCallback(func(error) { calls = append(calls, "primary") })
+	scope.AddEventCallback(func(e Event) {
+		if e.Ignored {
+			calls = append(calls, "ignored")
+		} else {
+			calls = append(calls, "reported")
+		}
+	}, IncludeIgnored())
+	scope.AddCallback(func(error) { calls = append(calls, "plain") })
+
+	scope.Except(os.ErrExist)
+	scope.Except(os.ErrClosed, os.ErrClosed)
+
+	if got, want := strings.Join(calls, ","), "reported,plain,primary,ignored"; got != want {
+		t.Errorf(`callbacks failed: got: %q, want: %q`, got, want)
+	}
+}
+
+func TestRegisterCallbackAdapts(t *testing.T) {
+	var got error
+	scope := NewScope()
+	scope.RegisterCallback(func(err error) { got = err })
+
+	scope.Callback()(Event{Record: Record{Err: os.ErrExist}})
+	if got != os.ErrExist {
+		t.Errorf(`Callback()(%v) failed: got: %v`, os.ErrExist, got)
+	}
+}
+
+func TestNewEventDetails(t *testing.T) {
+	scope := NewScope()
+	scope.RegisterCallback(func(error) {})
+	record := Record{Err: os.ErrExist}
+
+	if e := scope.state.Load().newEvent(record, nil); e.File != "" || e.Goroutine != 0 || e.Ignore != nil {
+		t.Errorf(`newEvent() failed: got details for adapted callbacks: %+v`, e)
+	}
+
+	handle := scope.AddEventCallback(func(Event) {})
+	if e := scope.state.Load().newEvent(record, nil); e.File == "" || e.Goroutine == 0 {
+		t.Errorf(`newEvent() failed: got no details for event callback: %+v`, e)
+	}
+
+	handle.Unregister()
+	if e := scope.state.Load().newEvent(record, nil); e.File != "" {
+		t.Errorf(`newEvent() failed: got details after unregister: %+v`, e)
+	}
+}
@@ -1,36 +1,41 @@
 package errors
 
-import "errors"
+// Handler of errors, e.g. *Scope or *Collector.
+type Handler interface {
+	Except(err error, ignore ...error)
+}
 
 // Handle error if not nil, and not among ignored ones.
 func Except(err error, ignore ...error) {
-	if err == nil {
-		return
-	}
-
-	lastError.store(err)
-
-	for _, e := range ignore {
-		if errors.Is(err, e) {
-			return
-		}
-	}
-
-	callback.fn(err)
+	defaultScope.Except(err, ignore...)
 }
 
 // Handle error if not nil, and not among ignored ones.
 // Return anything from fn except for error if successful.
 func ExceptFn[T any](fn ErrorFn[T], ignore ...error) T {
-	t, err := fn()
-	Except(err, ignore...)
-	return t
+	return ExceptFnIn(defaultScope, fn, ignore...)
 }
 
 // Handle error if not nil, and not among ignored ones.
 // Return anything from fn except for error if successful.
 func ExceptFn2[T, U any](fn ErrorFn2[T, U], ignore ...error) (T, U) {
+	return ExceptFn2In(defaultScope, fn, ignore...)
+}
+
+// Handle error through handler if not nil, and not among ignored ones.
+// Return anything from fn except for error if successful.
+// Go does not support generic methods, hence the handler is passed explicitly.
+func ExceptFnIn[T any](h Handler, fn ErrorFn[T], ignore ...error) T {
+	t, err := fn()
+	h.Except(err, ignore...)
+	return t
+}
+
+// Handle error through handler if not nil, and not among ignored ones.
+// Return anything from fn except for error if successful.
+// Go does not support generic methods, hence the handler is passed explicitly.
+func ExceptFn2In[T, U any](h Handler, fn ErrorFn2[T, U], ignore ...error) (T, U) {
 	t, u, err := fn()
-	Except(err, ignore...)
+	h.Except(err, ignore...)
 	return t, u
 }
@@ -24,6 +24,8 @@ func TestExcept(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			buffer := bytes.NewBuffer(nil)
 			RegisterCallback(func(err error) { buffer.WriteString(err.Error()) })
+			defer RestoreCallback()
+
 			Except(tt.args.err, tt.args.ignore...)
 			got := buffer.String()
 
@@ -51,6 +53,8 @@ func TestExceptFn(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			buffer := bytes.NewBuffer(nil)
 			RegisterCallback(func(err error) { buffer.WriteString(err.Error()) })
+			defer RestoreCallback()
+
 			ret := ExceptFn(tt.args.fn, tt.args.ignore...)
 			got := buffer.String()
 
@@ -82,6 +86,8 @@ func TestExceptFn2(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			buffer := bytes.NewBuffer(nil)
 			RegisterCallback(func(err error) { buffer.WriteString(err.Error()) })
+			defer RestoreCallback()
+
 			ret1, ret2 := ExceptFn2(tt.args.fn, tt.args.ignore...)
 			got := buffer.String()
 
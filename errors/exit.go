@@ -0,0 +1,120 @@
+package errors
+
+import (
+	"os"
+	"sync"
+)
+
+// Exit status of errors not matching any rule.
+const DefaultExitCode = 1
+
+// Registry of exit codes and cleanup functions (thread-safe).
+var exits = &exitRegistry{exit: os.Exit}
+
+// Registry of exit codes and cleanup functions.
+type exitRegistry struct {
+	rules    []exitRule
+	cleanups []func()
+	exit     func(code int)
+	sync.Mutex
+}
+
+// Exit code of errors matching target.
+type exitRule struct {
+	target error
+	code   int
+}
+
+// Register exit code of errors matching any of targets (see Matches).
+// Rules are evaluated in order of registration.
+func RegisterExitCode(code int, targets ...error) {
+	exits.Lock()
+	defer exits.Unlock()
+
+	for _, target := range targets {
+		exits.rules = append(exits.rules, exitRule{target: target, code: code})
+	}
+}
+
+// Register exit code of errors carrying any of codes (see CodeOf).
+func RegisterExitCodeOf(code int, codes ...Code) {
+	RegisterExitCode(code, MatchCode(codes...))
+}
+
+// Remove registered exit codes, e.g. in tests.
+func ResetExitCodes() {
+	exits.Lock()
+	defer exits.Unlock()
+
+	exits.rules = nil
+}
+
+// Retrieve exit code of error: 0 if nil, code of first matching rule, or DefaultExitCode.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	exits.Lock()
+	defer exits.Unlock()
+
+	for _, rule := range exits.rules {
+		if Matches(err, rule.target) {
+			return rule.code
+		}
+	}
+
+	return DefaultExitCode
+}
+
+// Register cleanup function run by Exit before exiting.
+// Cleanup functions run in reverse order of registration, once.
+func AtExit(fn func()) {
+	exits.Lock()
+	defer exits.Unlock()
+
+	exits.cleanups = append(exits.cleanups, fn)
+}
+
+// Replace function used to exit the process, e.g. in tests.
+// Return function restoring the previous one.
+func SetExitFunc(fn func(code int)) (restore func()) {
+	exits.Lock()
+	defer exits.Unlock()
+
+	previous := exits.exit
+	exits.exit = fn
+	return func() {
+		exits.Lock()
+		defer exits.Unlock()
+
+		exits.exit = previous
+	}
+}
+
+// Handle error like Except, run cleanup functions and exit with status of error (see ExitCode).
+// Exit with status 0 if error is nil or ignored.
+func Exit(err error, ignore ...error) {
+	defaultScope.Exit(err, ignore...)
+}
+
+// Handle error like Except, run cleanup functions and exit with status of error (see ExitCode).
+// Exit with status 0 if error is nil or ignored.
+// Mode of scope does not apply (see Mode), the process exits either way.
+func (s *Scope) Exit(err error, ignore ...error) {
+	terminate(ExitCode(s.handle(err, ignore)))
+}
+
+// Run cleanup functions in reverse order of registration and exit with code.
+func terminate(code int) {
+	exits.Lock()
+	cleanups, exit := exits.cleanups, exits.exit
+	exits.cleanups = nil
+	exits.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+
+	exit(code)
+}
@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+var (
+	errTestUsage    = errors.New("usage")
+	errTestConflict = Register(CodedError{Code: "test.exit.conflict", Message: "conflict"})
+)
+
+// Register exit codes of test errors until the test ends.
+func registerTestExitCodes(t *testing.T) {
+	RegisterExitCode(2, errTestUsage)
+	RegisterExitCodeOf(3, errTestConflict.Code)
+	t.Cleanup(ResetExitCodes)
+}
+
+func TestExitCode(t *testing.T) {
+	registerTestExitCodes(t)
+	for _, tt := range []struct {
+		name string
+		args error
+		want int
+	}{
+		{"test#1", nil, 0},
+		{"test#2", errTestUsage, 2},
+		{"test#3", fmt.Errorf("wrapped: %w", errTestUsage), 2},
+		{"test#4", errTestConflict.New("x"), 3},
+		{"test#5", os.ErrExist, DefaultExitCode},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.args); got != tt.want {
+				t.Errorf(`ExitCode(%v) failed: got: %d, want: %d`, tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExit(t *testing.T) {
+	registerTestExitCodes(t)
+	for _, tt := range []struct {
+		name   string
+		err    error
+		ignore []error
+		want   string
+	}{
+		{"test#1", errTestUsage, nil, "callback,second,first,exit 2"},
+		{"test#2", errTestUsage, []error{errTestUsage}, "second,first,exit 0"},
+		{"test#3", nil, nil, "second,first,exit 0"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			defer SetExitFunc(func(code int) { calls = append(calls, fmt.Sprintf("exit %d", code)) })()
+
+			scope := NewScope()
+			scope.RegisterCallback(func(error) { calls = append(calls, "callback") })
+			AtExit(func() { calls = append(calls, "first") })
+			AtExit(func() { calls = append(calls, "second") })
+
+			scope.Exit(tt.err, tt.ignore...)
+			scope.Exit(nil)
+			if got, want := strings.Join(calls, ","), tt.want+",exit 0"; got != want {
+				t.Errorf(`Exit(%v) failed: got: %q, want: %q`, tt.err, got, want)
+			}
+		})
+	}
+}
+
+func TestExitMode(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		mode Mode
+	}{
+		{"test#1", ModeExit},
+		{"test#2", ModePanic},
+		{"test#3", ModeStrict},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			defer SetExitFunc(func(code int) { calls = append(calls, fmt.Sprintf("exit %d", code)) })()
+
+			scope := NewScope().SetMode(tt.mode)
+			scope.RegisterCallback(func(error) {})
+			AtExit(func() { calls = append(calls, "cleanup") })
+
+			if err := Try(func() { scope.Exit(os.ErrExist) }); err != nil {
+				t.Errorf(`Exit() in %s mode failed: got: %v`, tt.mode, err)
+			}
+
+			if got, want := strings.Join(calls, ","), "cleanup,exit 1"; got != want {
+				t.Errorf(`Exit() in %s mode failed: got: %q, want: %q`, tt.mode, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package errors
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Key of field without value (see With).
+const badKey = "!BADKEY"
+
+// Error carrying key/value fields.
+type FieldsCarrier interface {
+	Fields() map[string]any
+}
+
+// Error annotated with key/value fields.
+type withFields struct {
+	err    error
+	fields map[string]any
+}
+
+// Return message of wrapped error.
+func (e *withFields) Error() string { return e.err.Error() }
+
+// Return wrapped error.
+func (e *withFields) Unwrap() error { return e.err }
+
+// Implement FieldsCarrier.
+func (e *withFields) Fields() map[string]any { return e.fields }
+
+// Annotate error with key/value pairs, e.g. With(err, "user", id, "attempt", n).
+// Keys are converted to strings, value without key is stored under "!BADKEY".
+// Return nil for nil error.
+func With(err error, kv ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	fields := make(map[string]any, (len(kv)+1)/2)
+	for i := 0; i < len(kv); i += 2 {
+		if i+1 == len(kv) {
+			fields[badKey] = kv[i]
+			break
+		}
+
+		key, ok := kv[i].(string)
+		if !ok {
+			key = fmt.Sprint(kv[i])
+		}
+
+		fields[key] = kv[i+1]
+	}
+
+	return &withFields{err: err, fields: fields}
+}
+
+// Merge fields of FieldsCarrier errors along the chain.
+// Fields of outer errors take precedence.
+func Fields(err error) map[string]any {
+	if err == nil {
+		return nil
+	}
+
+	_, _, _, fields := describe(err)
+	return fields
+}
+
+// Walk error chain depth-first and collect type names, wrapped messages,
+// errors.Join members and fields. Fields of outer errors take precedence.
+func describe(err error) (types, chain, joined []string, fields map[string]any) {
+	var walk func(error)
+	walk = func(e error) {
+		types = append(types, fmt.Sprintf("%T", e))
+		if c, ok := e.(FieldsCarrier); ok {
+			for key, value := range c.Fields() {
+				if fields == nil {
+					fields = make(map[string]any)
+				}
+
+				if _, ok := fields[key]; !ok {
+					fields[key] = value
+				}
+			}
+		}
+
+		switch u := e.(type) {
+		case interface{ Unwrap() error }:
+			if next := u.Unwrap(); next != nil {
+				chain = append(chain, next.Error())
+				walk(next)
+			}
+
+		case interface{ Unwrap() []error }:
+			for _, member := range u.Unwrap() {
+				if member != nil {
+					joined = append(joined, member.Error())
+					walk(member)
+				}
+			}
+
+		}
+	}
+
+	walk(err)
+	return
+}
+
+// Format fields as sorted key=value pairs.
+func formatFields(fields map[string]any) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = fmt.Sprintf("%s=%v", key, fields[key])
+	}
+
+	return strings.Join(pairs, " ")
+}
+
+// Handle error annotated with fields if not nil, and not among ignored ones.
+// Fields are passed as map rather than key/value pairs like to With,
+// since the variadic parameter is taken by the ignore list.
+func ExceptWith(err error, fields map[string]any, ignore ...error) {
+	defaultScope.ExceptWith(err, fields, ignore...)
+}
+
+// Handle error annotated with fields if not nil, and not among ignored ones.
+// Return anything from fn except for error if successful.
+func ExceptFnWith[T any](fn ErrorFn[T], fields map[string]any, ignore ...error) T {
+	t, err := fn()
+	defaultScope.ExceptWith(err, fields, ignore...)
+	return t
+}
+
+// Handle error annotated with fields if not nil, and not among ignored ones.
+func (s *Scope) ExceptWith(err error, fields map[string]any, ignore ...error) {
+	if err == nil {
+		return
+	}
+
+	copied := make(map[string]any, len(fields))
+	for key, value := range fields {
+		copied[key] = value
+	}
+
+	s.Except(&withFields{err: err, fields: copied}, ignore...)
+}
@@ -0,0 +1,88 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWith(t *testing.T) {
+	if With(nil, "key", "value") != nil {
+		t.Errorf(`With(nil) failed: got non-nil`)
+	}
+
+	for _, tt := range []struct {
+		name string
+		err  error
+		want map[string]any
+	}{
+		{"test#1", os.ErrExist, nil},
+		{"test#2", With(os.ErrExist, "user", 1, "path", "file.txt"), map[string]any{"user": 1, "path": "file.txt"}},
+		{"test#3", With(os.ErrExist, 1, 2, "odd"), map[string]any{"1": 2, badKey: "odd"}},
+		{"test#4", With(fmt.Errorf("wrapped: %w", With(os.ErrExist, "user", 1, "attempt", 1)), "attempt", 2), map[string]any{"user": 1, "attempt": 2}},
+		{"test#5", errors.Join(With(os.ErrExist, "a", 1), With(os.ErrClosed, "b", 2)), map[string]any{"a": 1, "b": 2}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Fields(tt.err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf(`Fields(%v) failed: got: %v, want: %v`, tt.err, got, tt.want)
+			}
+
+			if !errors.Is(tt.err, os.ErrExist) {
+				t.Errorf(`errors.Is(%v, %v) failed`, tt.err, os.ErrExist)
+			}
+		})
+	}
+
+	var pathError *fs.PathError
+	if err := With(&fs.PathError{Op: "open", Path: "file.txt", Err: os.ErrNotExist}, "k", "v"); !errors.As(err, &pathError) || err.Error() != "open file.txt: file does not exist" {
+		t.Errorf(`With() failed: got: %v`, err)
+	}
+}
+
+func TestExceptWith(t *testing.T) {
+	var got error
+	scope := NewScope()
+	scope.RegisterCallback(func(err error) { got = err })
+
+	fields := map[string]any{"user": 1}
+	scope.ExceptWith(nil, fields)
+	scope.ExceptWith(os.ErrExist, fields)
+	fields["user"] = 2
+
+	if !errors.Is(got, os.ErrExist) || !reflect.DeepEqual(Fields(got), map[string]any{"user": 1}) {
+		t.Errorf(`ExceptWith() failed: got: %v, fields: %v`, got, Fields(got))
+	}
+
+	RegisterCallback(func(err error) { got = err })
+	defer RestoreCallback()
+
+	if v := ExceptFnWith(W(1, os.ErrClosed), map[string]any{"path": "file.txt"}, os.ErrExist); v != 1 || !reflect.DeepEqual(Fields(got), map[string]any{"path": "file.txt"}) {
+		t.Errorf(`ExceptFnWith() failed: got: %v, fields: %v`, got, Fields(got))
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want func(string) bool
+	}{
+		{"test#1", os.ErrExist, func(s string) bool { return s == os.ErrExist.Error() }},
+		{"test#2", With(os.ErrExist, "user", 1, "path", "file.txt"), func(s string) bool {
+			return s == os.ErrExist.Error()+" path=file.txt user=1"
+		}},
+		{"test#3", WithStack(With(os.ErrExist, "user", 1)), func(s string) bool {
+			return strings.HasPrefix(s, os.ErrExist.Error()+" user=1\n") && strings.Contains(s, "fields_test.go:")
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatError(tt.err); !tt.want(got) {
+				t.Errorf(`formatError(%v) failed: got: %q`, tt.err, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Launcher of goroutines whose errors are handled like Except.
+// Zero value is usable, with default scope and no limit.
+type Group struct {
+	scope         *Scope
+	ignore        []error
+	cancel        context.CancelCauseFunc
+	cancelOnError bool
+	sem           chan struct{}
+	errs          []error
+	wg            sync.WaitGroup
+	mu            sync.Mutex
+}
+
+// Option of Group.
+type GroupOption func(*Group)
+
+// Limit number of concurrently running goroutines.
+func WithLimit(n int) GroupOption {
+	return func(g *Group) {
+		if n > 0 {
+			g.sem = make(chan struct{}, n)
+		}
+	}
+}
+
+// Cancel derived context on first error which is not ignored.
+// The error is available through context.Cause.
+func WithCancelOnError() GroupOption {
+	return func(g *Group) { g.cancelOnError = true }
+}
+
+// Ignore errors of goroutines among these ones (see Matches).
+func WithIgnore(ignore ...error) GroupOption {
+	return func(g *Group) { g.ignore = append(g.ignore, ignore...) }
+}
+
+// Create group handling errors through scope carried by context (see ScopeFrom).
+// Derived context is cancelled when Wait returns, or on first error with WithCancelOnError.
+func NewGroup(ctx context.Context, opts ...GroupOption) (*Group, context.Context) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := &Group{scope: ScopeFrom(ctx), cancel: cancel}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	return g, ctx
+}
+
+// Run fn in new goroutine and handle its error like Except.
+// Block while the limit of running goroutines is reached.
+func (g *Group) Go(fn func() error) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		defer func() {
+			if g.sem != nil {
+				<-g.sem
+			}
+		}()
+
+		g.handle(fn())
+	}()
+}
+
+// Handle error of goroutine.
+func (g *Group) handle(err error) {
+	scope := g.scope
+	if scope == nil {
+		scope = defaultScope
+	}
+
+	if err = scope.handle(err, g.ignore); err == nil {
+		return
+	}
+
+	g.mu.Lock()
+	g.errs = append(g.errs, err)
+	g.mu.Unlock()
+
+	if g.cancelOnError && g.cancel != nil {
+		g.cancel(err)
+	}
+}
+
+// Wait for all goroutines and return their errors which were not ignored, joined (errors.Join).
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(context.Canceled)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return errors.Join(g.errs...)
+}
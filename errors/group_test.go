@@ -0,0 +1,116 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	var reported atomic.Int32
+	scope := NewScope()
+	scope.RegisterCallback(func(error) { reported.Add(1) })
+
+	g, ctx := NewGroup(WithScope(context.Background(), scope), WithIgnore(os.ErrClosed), WithLimit(2))
+
+	var running, peak atomic.Int32
+	for _, err := range []error{nil, os.ErrExist, fmt.Errorf("wrapped: %w", os.ErrClosed), os.ErrNotExist, nil} {
+		err := err
+		g.Go(func() error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+
+			return err
+		})
+	}
+
+	err := g.Wait()
+	if !errors.Is(err, os.ErrExist) || !errors.Is(err, os.ErrNotExist) || errors.Is(err, os.ErrClosed) {
+		t.Errorf(`Wait() failed: got: %v`, err)
+	}
+
+	if peak.Load() > 2 {
+		t.Errorf(`Go() failed: got %d concurrent goroutines, want at most 2`, peak.Load())
+	}
+
+	if reported.Load() != 2 || scope.CountMatching(os.ErrClosed) != 1 || scope.LastError() == nil {
+		t.Errorf(`Go() failed: got %d reported, history: %v`, reported.Load(), scope.History())
+	}
+
+	if ctx.Err() == nil {
+		t.Errorf(`Wait() failed: context not cancelled`)
+	}
+}
+
+func TestGroupCancelOnError(t *testing.T) {
+	scope := NewScope()
+	scope.RegisterCallback(func(error) {})
+
+	g, ctx := NewGroup(WithScope(context.Background(), scope), WithCancelOnError(), WithIgnore(os.ErrClosed))
+	g.Go(func() error { return os.ErrClosed })
+	g.Go(func() error { return os.ErrExist })
+	g.Go(func() error {
+		<-ctx.Done()
+		return nil
+	})
+
+	if err := g.Wait(); !errors.Is(err, os.ErrExist) || !errors.Is(context.Cause(ctx), os.ErrExist) {
+		t.Errorf(`Wait() failed: got: %v, cause: %v`, err, context.Cause(ctx))
+	}
+}
+
+func TestGroupZero(t *testing.T) {
+	RegisterCallback(func(error) {})
+	defer RestoreCallback()
+
+	var g Group
+	g.Go(func() error { return os.ErrExist })
+	if err := g.Wait(); !errors.Is(err, os.ErrExist) || !LastErrorWas(os.ErrExist) {
+		t.Errorf(`Wait() failed: got: %v`, err)
+	}
+}
+
+func TestGroupPanicMode(t *testing.T) {
+	scope := NewScope().SetMode(ModePanic)
+	scope.RegisterCallback(func(error) {})
+
+	g, _ := NewGroup(WithScope(context.Background(), scope))
+	g.Go(func() error { return os.ErrExist })
+
+	if err := g.Wait(); !errors.Is(err, os.ErrExist) {
+		t.Errorf(`Wait() in panic mode failed: got: %v, want: %v`, err, os.ErrExist)
+	}
+}
+
+func TestGroupEventCallSite(t *testing.T) {
+	var files []string
+	var mu sync.Mutex
+	scope := NewScope()
+	scope.RegisterEventCallback(func(e Event) {
+		mu.Lock()
+		files = append(files, e.File)
+		mu.Unlock()
+	})
+
+	g, _ := NewGroup(WithScope(context.Background(), scope))
+	g.Go(func() error { return os.ErrExist })
+	g.Go(func() error { return WithStack(os.ErrClosed) })
+	_ = g.Wait()
+
+	sort.Strings(files)
+	if len(files) != 2 || files[0] != "" || !strings.HasSuffix(files[1], "group_test.go") {
+		t.Errorf(`Go() failed: got call sites: %q`, files)
+	}
+}
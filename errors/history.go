@@ -0,0 +1,164 @@
+package errors
+
+import (
+	"sync"
+	"time"
+)
+
+// Default capacity of error history.
+const DefaultHistoryCapacity = 16
+
+// Entry of error history.
+type Record struct {
+	Err     error
+	Code    Code
+	Time    time.Time
+	Ignored bool
+}
+
+// Bounded ring buffer of recent errors (thread-safe).
+type errorHistory struct {
+	records []Record
+	start   int
+	size    int
+	sync.Mutex
+}
+
+// Create history with given capacity.
+func newErrorHistory(capacity int) *errorHistory {
+	return (&errorHistory{}).resize(capacity)
+}
+
+// Change capacity, keeping most recent records.
+func (h *errorHistory) resize(capacity int) *errorHistory {
+	if capacity < 0 {
+		capacity = 0
+	}
+
+	h.Lock()
+	defer h.Unlock()
+
+	kept := h.snapshot()
+	if len(kept) > capacity {
+		kept = kept[len(kept)-capacity:]
+	}
+
+	h.records = make([]Record, capacity)
+	h.start, h.size = 0, copy(h.records, kept)
+
+	return h
+}
+
+// Append record, overwriting the oldest one if full.
+func (h *errorHistory) push(r Record) {
+	h.Lock()
+	defer h.Unlock()
+
+	if len(h.records) == 0 {
+		return
+	}
+
+	if h.size < len(h.records) {
+		h.records[(h.start+h.size)%len(h.records)] = r
+		h.size++
+		return
+	}
+
+	h.records[h.start] = r
+	h.start = (h.start + 1) % len(h.records)
+}
+
+// Copy records, oldest first (caller must hold the lock).
+func (h *errorHistory) snapshot() []Record {
+	out := make([]Record, 0, h.size)
+	for i := 0; i < h.size; i++ {
+		out = append(out, h.records[(h.start+i)%len(h.records)])
+	}
+
+	return out
+}
+
+// Copy records, oldest first.
+func (h *errorHistory) read() []Record {
+	h.Lock()
+	defer h.Unlock()
+
+	return h.snapshot()
+}
+
+// Copy records, oldest first, and clear history.
+func (h *errorHistory) drain() []Record {
+	h.Lock()
+	defer h.Unlock()
+
+	out := h.snapshot()
+	clear(h.records)
+	h.start, h.size = 0, 0
+
+	return out
+}
+
+// Set capacity of error history. Zero disables history.
+func (s *Scope) SetHistoryCapacity(capacity int) *Scope {
+	s.history.resize(capacity)
+	return s
+}
+
+// Retrieve recent errors, oldest first.
+func (s *Scope) History() []Record {
+	return s.history.read()
+}
+
+// Retrieve recent errors occurred at or after t, oldest first.
+func (s *Scope) HistorySince(t time.Time) []Record {
+	var out []Record
+	for _, r := range s.history.read() {
+		if !r.Time.Before(t) {
+			out = append(out, r)
+		}
+	}
+
+	return out
+}
+
+// Count recent errors matching target (see Matches).
+func (s *Scope) CountMatching(target error) int {
+	count := 0
+	for _, r := range s.history.read() {
+		if Matches(r.Err, target) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Retrieve recent errors, oldest first, and clear history.
+func (s *Scope) Drain() []Record {
+	return s.history.drain()
+}
+
+// Set capacity of error history in default scope. Zero disables history.
+func SetHistoryCapacity(capacity int) {
+	defaultScope.SetHistoryCapacity(capacity)
+}
+
+// Retrieve recent errors of default scope, oldest first.
+func History() []Record {
+	return defaultScope.History()
+}
+
+// Retrieve recent errors of default scope occurred at or after t, oldest first.
+func HistorySince(t time.Time) []Record {
+	return defaultScope.HistorySince(t)
+}
+
+// Count recent errors of default scope matching target.
+func CountMatching(target error) int {
+	return defaultScope.CountMatching(target)
+}
+
+// Retrieve recent errors of default scope, oldest first, and clear history.
+func Drain() []Record {
+	return defaultScope.Drain()
+}
@@ -0,0 +1,90 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	scope := NewScope().SetHistoryCapacity(3)
+	scope.RegisterCallback(func(error) {})
+
+	start := time.Now()
+	scope.Except(os.ErrExist)
+	scope.Except(os.ErrNotExist, os.ErrNotExist)
+	scope.Except(fmt.Errorf("wrapped: %w", os.ErrExist))
+	scope.Except(os.ErrClosed)
+
+	history := scope.History()
+	if len(history) != 3 {
+		t.Fatalf(`History() failed: got %d records, want 3`, len(history))
+	}
+
+	for i, tt := range []struct {
+		err     error
+		ignored bool
+	}{
+		{os.ErrNotExist, true},
+		{os.ErrExist, false},
+		{os.ErrClosed, false},
+	} {
+		if !errors.Is(history[i].Err, tt.err) || history[i].Ignored != tt.ignored {
+			t.Errorf(`History()[%d] failed: got: %+v, want: {%v %t}`, i, history[i], tt.err, tt.ignored)
+		}
+	}
+
+	if got := scope.CountMatching(os.ErrExist); got != 1 {
+		t.Errorf(`CountMatching(%v) failed: got: %d, want: 1`, os.ErrExist, got)
+	}
+
+	if got := len(scope.HistorySince(start)); got != 3 {
+		t.Errorf(`HistorySince(start) failed: got: %d, want: 3`, got)
+	}
+
+	if got := len(scope.HistorySince(time.Now().Add(time.Hour))); got != 0 {
+		t.Errorf(`HistorySince(future) failed: got: %d, want: 0`, got)
+	}
+
+	if got := len(scope.Drain()); got != 3 {
+		t.Errorf(`Drain() failed: got: %d, want: 3`, got)
+	}
+
+	if got := len(scope.History()); got != 0 {
+		t.Errorf(`History() after Drain() failed: got: %d, want: 0`, got)
+	}
+
+	if !scope.LastErrorWas(os.ErrClosed) {
+		t.Errorf(`LastErrorWas(%v) failed: got: %v`, os.ErrClosed, scope.LastError())
+	}
+}
+
+func TestHistoryResize(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		capacity int
+		want     int
+	}{
+		{"test#1", 0, 0},
+		{"test#2", 2, 2},
+		{"test#3", 10, 5},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newErrorHistory(5)
+			for i := 0; i < 5; i++ {
+				h.push(Record{Err: fmt.Errorf("%d", i)})
+			}
+
+			got := h.resize(tt.capacity).read()
+			if len(got) != tt.want {
+				t.Fatalf(`resize(%d) failed: got %d records, want %d`, tt.capacity, len(got), tt.want)
+			}
+
+			if tt.want > 0 && got[len(got)-1].Err.Error() != "4" {
+				t.Errorf(`resize(%d) failed: newest record lost: got: %v`, tt.capacity, got)
+			}
+		})
+	}
+}
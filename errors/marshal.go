@@ -0,0 +1,197 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// Registry of sentinels recognized by Unmarshal, keyed by type and message.
+var sentinels = &sentinelRegistry{errs: make(map[string]error)}
+
+func init() {
+	RegisterSentinel(
+		io.EOF, io.ErrUnexpectedEOF, io.ErrClosedPipe, io.ErrShortWrite,
+		os.ErrInvalid, os.ErrPermission, os.ErrExist, os.ErrNotExist, os.ErrClosed,
+		os.ErrNoDeadline, os.ErrDeadlineExceeded, os.ErrProcessDone,
+		context.Canceled, context.DeadlineExceeded,
+	)
+}
+
+// Registry of sentinels (thread-safe).
+type sentinelRegistry struct {
+	errs map[string]error
+	sync.RWMutex
+}
+
+// Create registry key of error.
+func sentinelKey(typ, message string) string {
+	return typ + "\x00" + message
+}
+
+// Register sentinels to be recognized by Unmarshal.
+// Sentinels are identified by type name and message.
+// Coded errors (see Register) are recognized by code instead.
+func RegisterSentinel(errs ...error) {
+	sentinels.Lock()
+	defer sentinels.Unlock()
+
+	for _, err := range errs {
+		sentinels.errs[sentinelKey(fmt.Sprintf("%T", err), err.Error())] = err
+	}
+}
+
+// Node of serialized error chain.
+type errorNode struct {
+	Message string         `json:"message"`
+	Type    string         `json:"type"`
+	Code    Code           `json:"code,omitempty"`
+	Fields  map[string]any `json:"fields,omitempty"`
+	Wrapped *errorNode     `json:"wrapped,omitempty"`
+	Joined  []*errorNode   `json:"joined,omitempty"`
+}
+
+// Error rebuilt by Unmarshal.
+// Matches registered sentinels and coded errors it was created from (errors.Is, errors.As).
+type RemoteError struct {
+	// Message of original error.
+	Message string
+
+	// Type name of original error.
+	Type string
+
+	// Code of original error, if it was coded.
+	Code Code
+
+	fields   map[string]any
+	wrapped  error
+	joined   []error
+	sentinel error
+	coded    *CodedError
+}
+
+// Return message of original error.
+func (e *RemoteError) Error() string { return e.Message }
+
+// Return wrapped error or joined errors.
+func (e *RemoteError) Unwrap() []error {
+	if e.wrapped != nil {
+		return []error{e.wrapped}
+	}
+
+	return e.joined
+}
+
+// Report whether target is the sentinel original error matched.
+func (e *RemoteError) Is(target error) bool {
+	return e.sentinel != nil && e.sentinel == target
+}
+
+// Convert to *CodedError if original error was coded.
+func (e *RemoteError) As(target any) bool {
+	if t, ok := target.(**CodedError); ok && e.coded != nil {
+		*t = e.coded
+		return true
+	}
+
+	return false
+}
+
+// Implement FieldsCarrier.
+func (e *RemoteError) Fields() map[string]any { return e.fields }
+
+// Serialize error chain into JSON tree of message, type, code, fields,
+// wrapped and joined errors. Serialize nil into null.
+func Marshal(err error) ([]byte, error) {
+	return json.Marshal(toNode(err))
+}
+
+// Rebuild error serialized by Marshal.
+// Return nil error for null.
+func Unmarshal(data []byte) (error, error) {
+	var node *errorNode
+	if err := json.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+
+	return fromNode(node), nil
+}
+
+// Convert error into node.
+func toNode(err error) *errorNode {
+	if err == nil {
+		return nil
+	}
+
+	node := &errorNode{Message: err.Error(), Type: fmt.Sprintf("%T", err)}
+	if c, ok := err.(FieldsCarrier); ok {
+		node.Fields = c.Fields()
+	}
+
+	switch e := err.(type) {
+	case *RemoteError:
+		node.Type, node.Code, node.Wrapped = e.Type, e.Code, toNode(e.wrapped)
+		for _, member := range e.joined {
+			node.Joined = append(node.Joined, toNode(member))
+		}
+
+		return node
+
+	case *CodedError:
+		node.Code = e.Code
+
+	}
+
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		node.Wrapped = toNode(u.Unwrap())
+
+	case interface{ Unwrap() []error }:
+		for _, member := range u.Unwrap() {
+			if member != nil {
+				node.Joined = append(node.Joined, toNode(member))
+			}
+		}
+
+	}
+
+	return node
+}
+
+// Convert node into error.
+func fromNode(node *errorNode) error {
+	if node == nil {
+		return nil
+	}
+
+	e := &RemoteError{
+		Message: node.Message,
+		Type:    node.Type,
+		Code:    node.Code,
+		fields:  node.Fields,
+		wrapped: fromNode(node.Wrapped),
+	}
+
+	for _, member := range node.Joined {
+		e.joined = append(e.joined, fromNode(member))
+	}
+
+	if node.Code != "" {
+		e.coded = &CodedError{Code: node.Code, Message: node.Message}
+		if def, ok := Lookup(node.Code); ok {
+			e.coded, e.sentinel = def.Wrap(nil), def
+			e.coded.Message = node.Message
+		}
+
+		return e
+	}
+
+	sentinels.RLock()
+	e.sentinel = sentinels.errs[sentinelKey(node.Type, node.Message)]
+	sentinels.RUnlock()
+
+	return e
+}
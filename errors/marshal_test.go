@@ -0,0 +1,118 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestMarshal(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"test#1", nil, `null`},
+		{"test#2", os.ErrExist, `{"message":"file already exists","type":"*errors.errorString"}`},
+		{"test#3", fmt.Errorf("get: %w", errTestNotFound.New("x")), `{"message":"get: item \"x\" not found","type":"*fmt.wrapError",` +
+			`"wrapped":{"message":"item \"x\" not found","type":"*errors.CodedError","code":"test.not_found"}}`},
+		{"test#4", errors.Join(fieldsError{os.ErrExist, map[string]any{"path": "file.txt"}}, context.Canceled), `{"message":"file already exists\ncontext canceled","type":"*errors.joinError",` +
+			`"joined":[{"message":"file already exists","type":"errors.fieldsError","fields":{"path":"file.txt"},"wrapped":{"message":"file already exists","type":"*errors.errorString"}},` +
+			`{"message":"context canceled","type":"*errors.errorString"}]}`},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.err)
+			if err != nil {
+				t.Fatalf(`Marshal(%v) failed: %v`, tt.err, err)
+			}
+
+			var gotTree, wantTree any
+			if err := json.Unmarshal(got, &gotTree); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := json.Unmarshal([]byte(tt.want), &wantTree); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(gotTree, wantTree) {
+				t.Errorf(`Marshal(%v) failed: got: %s, want: %s`, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		err     error
+		targets []error
+	}{
+		{"test#1", nil, nil},
+		{"test#2", fmt.Errorf("open: %w", os.ErrNotExist), []error{os.ErrNotExist}},
+		{"test#3", fmt.Errorf("get: %w", errTestNotFound.New("x")), []error{errTestNotFound}},
+		{"test#4", errors.Join(os.ErrExist, fmt.Errorf("wait: %w", context.DeadlineExceeded)), []error{os.ErrExist, context.DeadlineExceeded}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Marshal(tt.err)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Unmarshal(data)
+			if err != nil {
+				t.Fatalf(`Unmarshal(%s) failed: %v`, data, err)
+			}
+
+			if tt.err == nil {
+				if got != nil {
+					t.Errorf(`Unmarshal(%s) failed: got: %v, want: nil`, data, got)
+				}
+
+				return
+			}
+
+			if got.Error() != tt.err.Error() || CodeOf(got) != CodeOf(tt.err) || StatusOf(got) != StatusOf(tt.err) {
+				t.Errorf(`Unmarshal(%s) failed: got: %v (%q), want: %v (%q)`, data, got, CodeOf(got), tt.err, CodeOf(tt.err))
+			}
+
+			for _, target := range tt.targets {
+				if !errors.Is(got, target) {
+					t.Errorf(`errors.Is(%v, %v) failed`, got, target)
+				}
+			}
+
+			again, err := Marshal(got)
+			if err != nil || string(again) != string(data) {
+				t.Errorf(`Marshal(Unmarshal(%s)) failed: got: %s, %v`, data, again, err)
+			}
+
+			if errors.Is(got, os.ErrClosed) {
+				t.Errorf(`errors.Is(%v, %v) failed: unexpected match`, got, os.ErrClosed)
+			}
+		})
+	}
+
+	if _, err := Unmarshal([]byte(`{`)); err == nil {
+		t.Errorf(`Unmarshal("{") failed: got nil error`)
+	}
+}
+
+func TestUnmarshalLastErrorWas(t *testing.T) {
+	remote, err := Unmarshal([]byte(`{"message":"file does not exist","type":"*errors.errorString"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scope := NewScope()
+	scope.RegisterCallback(func(error) { t.Errorf(`Except(%v) failed: not ignored`, remote) })
+	scope.Except(remote, os.ErrNotExist)
+
+	if !scope.LastErrorWas(os.ErrNotExist) {
+		t.Errorf(`LastErrorWas(%v) failed: got: %v`, os.ErrNotExist, scope.LastError())
+	}
+}
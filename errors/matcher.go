@@ -0,0 +1,140 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"syscall"
+)
+
+// Matcher of errors, usable wherever sentinel errors are accepted
+// for matching (ignore lists, RetryPolicy and alike).
+type Matcher interface {
+	error
+
+	// Report whether err matches.
+	Match(err error) bool
+}
+
+// Matcher defined by description and function.
+type matcher struct {
+	desc string
+	fn   func(error) bool
+}
+
+// Return description of matcher.
+func (m *matcher) Error() string { return "matcher: " + m.desc }
+
+// Implement Matcher.
+func (m *matcher) Match(err error) bool { return m.fn(err) }
+
+// Report whether err matches any of targets.
+// Matchers are applied directly, other errors are compared with errors.Is.
+func Matches(err error, targets ...error) bool {
+	for _, target := range targets {
+		if m, ok := target.(Matcher); ok {
+			if m.Match(err) {
+				return true
+			}
+
+			continue
+		}
+
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Match errors convertible to T (errors.As).
+func MatchAs[T error]() Matcher {
+	var zero T
+	return &matcher{fmt.Sprintf("as %T", zero), func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}}
+}
+
+// Match errors whose message matches regular expression.
+func MatchMessage(re *regexp.Regexp) Matcher {
+	return &matcher{fmt.Sprintf("message %q", re), func(err error) bool {
+		return err != nil && re.MatchString(err.Error())
+	}}
+}
+
+// Match errors satisfying predicate.
+func MatchFunc(pred func(error) bool) Matcher {
+	return &matcher{"predicate", pred}
+}
+
+// Match errors carrying any of the errno values (errors.As).
+func MatchErrno(errnos ...syscall.Errno) Matcher {
+	return &matcher{fmt.Sprintf("errno %v", errnos), func(err error) bool {
+		var errno syscall.Errno
+		if !errors.As(err, &errno) {
+			return false
+		}
+
+		for _, e := range errnos {
+			if errno == e {
+				return true
+			}
+		}
+
+		return false
+	}}
+}
+
+// Match errors whose code (see CodeOf) is any of codes.
+func MatchCode(codes ...Code) Matcher {
+	return &matcher{fmt.Sprintf("code %q", codes), func(err error) bool {
+		code := CodeOf(err)
+		for _, c := range codes {
+			if code != "" && code == c {
+				return true
+			}
+		}
+
+		return false
+	}}
+}
+
+// Match errors not matching target.
+func Not(target error) Matcher {
+	return &matcher{fmt.Sprintf("not (%v)", target), func(err error) bool {
+		return !Matches(err, target)
+	}}
+}
+
+// Match errors matching any of targets.
+func Any(targets ...error) Matcher {
+	return &matcher{"any (" + describeTargets(targets) + ")", func(err error) bool {
+		return Matches(err, targets...)
+	}}
+}
+
+// Match errors matching all of targets.
+func All(targets ...error) Matcher {
+	return &matcher{"all (" + describeTargets(targets) + ")", func(err error) bool {
+		for _, target := range targets {
+			if !Matches(err, target) {
+				return false
+			}
+		}
+
+		return true
+	}}
+}
+
+// Join descriptions of targets.
+func describeTargets(targets []error) string {
+	desc := make([]string, len(targets))
+	for i, target := range targets {
+		desc[i] = fmt.Sprint(target)
+	}
+
+	return strings.Join(desc, ", ")
+}
@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"syscall"
+	"testing"
+)
+
+func TestMatches(t *testing.T) {
+	pathError := &fs.PathError{Op: "open", Path: "file.txt", Err: syscall.ENOENT}
+	for _, tt := range []struct {
+		name   string
+		err    error
+		target error
+		want   bool
+	}{
+		{"test#1", os.ErrExist, os.ErrExist, true},
+		{"test#2", fmt.Errorf("wrapped: %w", os.ErrExist), os.ErrExist, true},
+		{"test#3", os.ErrExist, os.ErrClosed, false},
+		{"test#4", pathError, MatchAs[*fs.PathError](), true},
+		{"test#5", os.ErrExist, MatchAs[*fs.PathError](), false},
+		{"test#6", pathError, MatchMessage(regexp.MustCompile(`^open .*\.txt`)), true},
+		{"test#7", os.ErrExist, MatchMessage(regexp.MustCompile(`^open`)), false},
+		{"test#8", pathError, MatchErrno(syscall.EACCES, syscall.ENOENT), true},
+		{"test#9", pathError, MatchErrno(syscall.EACCES), false},
+		{"test#10", os.ErrExist, MatchFunc(func(err error) bool { return err == os.ErrExist }), true},
+		{"test#11", os.ErrExist, Not(os.ErrClosed), true},
+		{"test#12", os.ErrExist, Not(os.ErrExist), false},
+		{"test#13", pathError, Any(os.ErrClosed, MatchErrno(syscall.ENOENT)), true},
+		{"test#14", pathError, Any(os.ErrClosed, os.ErrExist), false},
+		{"test#15", pathError, All(os.ErrNotExist, MatchAs[*fs.PathError]()), true},
+		{"test#16", pathError, All(os.ErrNotExist, Not(MatchAs[*fs.PathError]())), false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Matches(tt.err, tt.target); got != tt.want {
+				t.Errorf(`Matches(%v, %v) failed: got: %t, want: %t`, tt.err, tt.target, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExceptMatcher(t *testing.T) {
+	buffer := bytes.NewBuffer(nil)
+	scope := NewScope().Ignore(MatchAs[*fs.PathError]())
+	scope.RegisterCallback(func(err error) { buffer.WriteString(err.Error()) })
+
+	scope.Except(&fs.PathError{Op: "open", Path: "file.txt", Err: os.ErrNotExist})
+	scope.Except(os.ErrExist, Not(os.ErrClosed))
+	scope.Except(os.ErrClosed, Not(os.ErrClosed))
+
+	if got := buffer.String(); got != os.ErrClosed.Error() {
+		t.Errorf(`Except() failed: got: %q, want: %q`, got, os.ErrClosed)
+	}
+
+	if !scope.LastErrorWas(MatchMessage(regexp.MustCompile(`closed`))) {
+		t.Errorf(`LastErrorWas() failed: got: %v`, scope.LastError())
+	}
+}
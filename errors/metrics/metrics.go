@@ -0,0 +1,199 @@
+// Package metrics counts errors handled by scopes of package errors
+// and exports them through expvar or in Prometheus text format.
+package metrics
+
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+
+	supererrors "github.com/sarumaj/go-super/errors"
+)
+
+// Interval of rates if not set in Options.
+const DefaultInterval = time.Minute
+
+// Options of Metrics.
+type Options struct {
+	// Interval over which rates are computed, defaults to DefaultInterval.
+	Interval time.Duration
+
+	// Prefix of metric names in Prometheus format, defaults to "supererrors".
+	Namespace string
+
+	// Source of time, defaults to system clock.
+	Clock supererrors.Clock
+}
+
+// Counters of handled errors.
+type Counters struct {
+	// Number of handled errors.
+	Total uint64 `json:"total"`
+
+	// Number of errors passed to callbacks.
+	Reported uint64 `json:"reported"`
+
+	// Number of ignored errors.
+	Ignored uint64 `json:"ignored"`
+}
+
+// Add record to counters.
+func (c *Counters) add(r supererrors.Record) {
+	c.Total++
+	if r.Ignored {
+		c.Ignored++
+	} else {
+		c.Reported++
+	}
+}
+
+// Rates of handled errors, per second.
+type Rates struct {
+	Total    float64 `json:"total"`
+	Reported float64 `json:"reported"`
+	Ignored  float64 `json:"ignored"`
+}
+
+// Point-in-time copy of metrics.
+type Snapshot struct {
+	Counters
+
+	// Counters by type name of root cause.
+	ByType map[string]Counters `json:"by_type"`
+
+	// Counters by code (see supererrors.CodeOf), coded errors only.
+	ByCode map[string]Counters `json:"by_code"`
+
+	// Rates over last completed interval.
+	Rates Rates `json:"rates"`
+}
+
+// Metrics of handled errors (thread-safe).
+type Metrics struct {
+	opts     Options
+	counters Counters
+	byType   map[string]Counters
+	byCode   map[string]Counters
+	start    time.Time
+	current  Counters
+	rates    Rates
+	sync.Mutex
+}
+
+// Create empty metrics.
+func New(opts *Options) *Metrics {
+	m := &Metrics{byType: make(map[string]Counters), byCode: make(map[string]Counters)}
+	if opts != nil {
+		m.opts = *opts
+	}
+
+	if m.opts.Interval <= 0 {
+		m.opts.Interval = DefaultInterval
+	}
+
+	if m.opts.Namespace == "" {
+		m.opts.Namespace = "supererrors"
+	}
+
+	if m.opts.Clock == nil {
+		m.opts.Clock = supererrors.SystemClock
+	}
+
+	m.start = m.opts.Clock.Now()
+	return m
+}
+
+// Count errors handled by scope until returned handle is unregistered.
+func (m *Metrics) Attach(scope *supererrors.Scope) supererrors.CallbackHandle {
+	return scope.AddObserver(m.Observe)
+}
+
+// Count handled error. Implements supererrors.Observer.
+func (m *Metrics) Observe(r supererrors.Record) {
+	typ := fmt.Sprintf("%T", rootCause(r.Err))
+
+	m.Lock()
+	defer m.Unlock()
+
+	m.roll(m.opts.Clock.Now())
+	m.counters.add(r)
+	m.current.add(r)
+
+	c := m.byType[typ]
+	c.add(r)
+	m.byType[typ] = c
+
+	if r.Code != "" {
+		c := m.byCode[string(r.Code)]
+		c.add(r)
+		m.byCode[string(r.Code)] = c
+	}
+}
+
+// Complete elapsed intervals and compute rates (caller must hold the lock).
+func (m *Metrics) roll(now time.Time) {
+	elapsed := now.Sub(m.start)
+	if elapsed < m.opts.Interval {
+		return
+	}
+
+	seconds := m.opts.Interval.Seconds()
+	if elapsed >= 2*m.opts.Interval {
+		// nothing happened during last completed interval
+		m.current = Counters{}
+	}
+
+	m.rates = Rates{
+		Total:    float64(m.current.Total) / seconds,
+		Reported: float64(m.current.Reported) / seconds,
+		Ignored:  float64(m.current.Ignored) / seconds,
+	}
+
+	m.current = Counters{}
+	m.start = m.start.Add(elapsed.Truncate(m.opts.Interval))
+}
+
+// Retrieve copy of metrics.
+func (m *Metrics) Snapshot() Snapshot {
+	m.Lock()
+	defer m.Unlock()
+
+	m.roll(m.opts.Clock.Now())
+	s := Snapshot{
+		Counters: m.counters,
+		ByType:   make(map[string]Counters, len(m.byType)),
+		ByCode:   make(map[string]Counters, len(m.byCode)),
+		Rates:    m.rates,
+	}
+
+	for k, v := range m.byType {
+		s.ByType[k] = v
+	}
+
+	for k, v := range m.byCode {
+		s.ByCode[k] = v
+	}
+
+	return s
+}
+
+// Publish snapshot as expvar variable with given name.
+// Can be called only once per name within the process, since expvar variables
+// cannot be unregistered: panic if name is already registered (see expvar.Publish).
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() any { return m.Snapshot() }))
+}
+
+// Retrieve innermost error of single-error chain.
+func rootCause(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+
+		err = next
+	}
+}
@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	supererrors "github.com/sarumaj/go-super/errors"
+)
+
+var errTestCoded = supererrors.Register(supererrors.CodedError{Code: "metrics.test", Message: "test"})
+
+// Create scope observed by fresh metrics.
+func setup(t *testing.T) (*supererrors.Scope, *Metrics, *supererrors.FakeClock) {
+	clock := supererrors.NewFakeClock(time.Now())
+	scope, m := supererrors.NewScope(), New(&Options{Interval: time.Minute, Clock: clock})
+	scope.RegisterCallback(func(error) {})
+	t.Cleanup(m.Attach(scope).Unregister)
+
+	return scope, m, clock
+}
+
+func TestMetrics(t *testing.T) {
+	scope, m, clock := setup(t)
+
+	scope.Except(os.ErrExist)
+	scope.Except(fmt.Errorf("wrapped: %w", os.ErrExist), os.ErrExist)
+	scope.Except(errTestCoded.New())
+	clock.Advance(time.Minute)
+	scope.Except(os.ErrClosed)
+
+	got := m.Snapshot()
+	want := Snapshot{
+		Counters: Counters{Total: 4, Reported: 3, Ignored: 1},
+		ByType: map[string]Counters{
+			"*errors.errorString": {Total: 3, Reported: 2, Ignored: 1},
+			"*errors.CodedError":  {Total: 1, Reported: 1},
+		},
+		ByCode: map[string]Counters{"metrics.test": {Total: 1, Reported: 1}},
+		Rates:  Rates{Total: 3.0 / 60, Reported: 2.0 / 60, Ignored: 1.0 / 60},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf(`Snapshot() failed: got: %+v, want: %+v`, got, want)
+	}
+
+	clock.Advance(2 * time.Minute)
+	if got := m.Snapshot().Rates; got != (Rates{}) {
+		t.Errorf(`Snapshot() failed: got rates: %+v, want: none`, got)
+	}
+}
+
+// Number of expvar variables published by tests, making their names unique across runs (-count).
+var published atomic.Int32
+
+func TestPublish(t *testing.T) {
+	scope, m, _ := setup(t)
+	name := fmt.Sprintf("supererrors_%s_%d", t.Name(), published.Add(1))
+	m.Publish(name)
+	scope.Except(os.ErrExist)
+
+	var got Snapshot
+	if err := json.Unmarshal([]byte(expvar.Get(name).String()), &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Reported != 1 {
+		t.Errorf(`Publish() failed: got: %+v`, got)
+	}
+}
+
+func TestWritePrometheus(t *testing.T) {
+	scope, m, _ := setup(t)
+	scope.Except(errTestCoded.New())
+	scope.Except(os.ErrExist, os.ErrExist)
+
+	var buffer bytes.Buffer
+	if err := m.WritePrometheus(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"# TYPE supererrors_handled_total counter\n",
+		`supererrors_handled_total{outcome="reported"} 1` + "\n",
+		`supererrors_handled_total{outcome="ignored"} 1` + "\n",
+		`supererrors_handled_by_type_total{type="*errors.CodedError",outcome="reported"} 1` + "\n",
+		`supererrors_handled_by_type_total{type="*errors.errorString",outcome="ignored"} 1` + "\n",
+		`supererrors_handled_by_code_total{code="metrics.test",outcome="reported"} 1` + "\n",
+		"# TYPE supererrors_handled_rate gauge\n",
+	} {
+		if !strings.Contains(buffer.String(), want) {
+			t.Errorf(`WritePrometheus() failed: got: %s, want line: %q`, buffer.String(), want)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	if recorder.Body.String() != buffer.String() || !strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain") {
+		t.Errorf(`Handler() failed: got: %q`, recorder.Body.String())
+	}
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// Escaper of label values in Prometheus text format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// Write metrics in Prometheus text exposition format.
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	s, ns := m.Snapshot(), m.opts.Namespace
+	bw := bufio.NewWriter(w)
+
+	family := func(name, typ, help string) {
+		_, _ = fmt.Fprintf(bw, "# HELP %s_%s %s\n# TYPE %s_%s %s\n", ns, name, help, ns, name, typ)
+	}
+
+	sample := func(name string, labels []string, value any) {
+		_, _ = fmt.Fprintf(bw, "%s_%s", ns, name)
+		if len(labels) > 0 {
+			pairs := make([]string, 0, len(labels)/2)
+			for i := 0; i+1 < len(labels); i += 2 {
+				pairs = append(pairs, fmt.Sprintf(`%s="%s"`, labels[i], labelEscaper.Replace(labels[i+1])))
+			}
+
+			_, _ = fmt.Fprintf(bw, "{%s}", strings.Join(pairs, ","))
+		}
+
+		_, _ = fmt.Fprintf(bw, " %v\n", value)
+	}
+
+	family("handled_total", "counter", "Errors handled by Except.")
+	sample("handled_total", []string{"outcome", "reported"}, s.Reported)
+	sample("handled_total", []string{"outcome", "ignored"}, s.Ignored)
+
+	for _, group := range []struct {
+		name, label string
+		counters    map[string]Counters
+	}{
+		{"handled_by_type_total", "type", s.ByType},
+		{"handled_by_code_total", "code", s.ByCode},
+	} {
+		family(group.name, "counter", "Errors handled by Except, by "+group.label+".")
+		keys := make([]string, 0, len(group.counters))
+		for k := range group.counters {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+		for _, k := range keys {
+			sample(group.name, []string{group.label, k, "outcome", "reported"}, group.counters[k].Reported)
+			sample(group.name, []string{group.label, k, "outcome", "ignored"}, group.counters[k].Ignored)
+		}
+	}
+
+	family("handled_rate", "gauge", "Errors handled by Except per second over last interval.")
+	sample("handled_rate", []string{"outcome", "reported"}, s.Rates.Reported)
+	sample("handled_rate", []string{"outcome", "ignored"}, s.Rates.Ignored)
+
+	return bw.Flush()
+}
+
+// Create HTTP handler serving metrics in Prometheus text exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_ = m.WritePrometheus(w)
+	})
+}
@@ -0,0 +1,133 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Environment variable selecting mode of default scope at init.
+const ModeEnv = "GOSUPER_ERRORS"
+
+// Mode of handling reported errors.
+// Panic and exit apply to Except and alike only, not to errors
+// reported on behalf of the caller, e.g. by Retry or Group.
+type Mode string
+
+const (
+	// Pass errors to callbacks (default).
+	ModeLog Mode = "log"
+	// Pass errors to callbacks, then panic.
+	// The panic is meant to be recovered by Try or Catch.
+	ModePanic Mode = "panic"
+	// Pass errors to callbacks, then exit with status of error (see Exit).
+	ModeExit Mode = "exit"
+	// Only record errors (see LastError, History and observers), callbacks are skipped.
+	ModeSilent Mode = "silent"
+	// Like ModePanic, but panic on ignored errors not seen before as well.
+	// Up to StrictSeenCapacity kinds of ignored errors are remembered,
+	// any further ones are treated as not seen before.
+	ModeStrict Mode = "strict"
+)
+
+// Number of kinds of ignored errors remembered by scope in strict mode.
+const StrictSeenCapacity = 1024
+
+// Error raised in strict mode by ignored error not seen before.
+var ErrUnseenIgnored = errors.New("ignored error not seen before")
+
+func init() {
+	value, ok := os.LookupEnv(ModeEnv)
+	if !ok {
+		return
+	}
+
+	mode, err := ParseMode(value)
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		return
+	}
+
+	defaultScope.SetMode(mode)
+}
+
+// Parse name of mode (case-insensitive). Empty name denotes ModeLog.
+func ParseMode(name string) (Mode, error) {
+	switch mode := Mode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case "":
+		return ModeLog, nil
+
+	case ModeLog, ModePanic, ModeExit, ModeSilent, ModeStrict:
+		return mode, nil
+
+	default:
+		return "", fmt.Errorf("unknown mode %q of %s", name, ModeEnv)
+	}
+}
+
+// Set mode of default scope.
+func SetMode(mode Mode) {
+	defaultScope.SetMode(mode)
+}
+
+// Retrieve mode of default scope.
+func CurrentMode() Mode {
+	return defaultScope.Mode()
+}
+
+// Set mode of scope.
+func (s *Scope) SetMode(mode Mode) *Scope {
+	s.update(func(state *scopeState) { state.mode = mode })
+	return s
+}
+
+// Retrieve mode of scope.
+func (s *Scope) Mode() Mode {
+	if mode := s.state.Load().mode; mode != "" {
+		return mode
+	}
+
+	return ModeLog
+}
+
+// Fail as prescribed by mode after error was reported.
+func (mode Mode) fail(err error) {
+	switch mode {
+	case ModePanic, ModeStrict:
+		panic(&mustPanic{err: err})
+
+	case ModeExit:
+		terminate(ExitCode(err))
+	}
+}
+
+// Fail as prescribed by mode of scope if error is not nil.
+// Meant to be called by user-facing entry points after handle.
+func (s *Scope) fail(err error) {
+	if err != nil {
+		s.Mode().fail(err)
+	}
+}
+
+// Check if ignored error of this kind was seen before by scope, remember it otherwise.
+// Errors are identified by type and message.
+// Once StrictSeenCapacity kinds are remembered, new ones are not.
+func (s *Scope) seenBefore(err error) bool {
+	key := sentinelKey(fmt.Sprintf("%T", err), err.Error())
+	if _, ok := s.seen.Load(key); ok {
+		return true
+	}
+
+	if s.seenCount.Add(1) > StrictSeenCapacity {
+		s.seenCount.Add(-1)
+		return false
+	}
+
+	_, loaded := s.seen.LoadOrStore(key, struct{}{})
+	if loaded {
+		s.seenCount.Add(-1)
+	}
+
+	return loaded
+}
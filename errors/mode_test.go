@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestParseMode(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		args    string
+		want    Mode
+		wantErr bool
+	}{
+		{"test#1", "", ModeLog, false},
+		{"test#2", "panic", ModePanic, false},
+		{"test#3", " Strict ", ModeStrict, false},
+		{"test#4", "EXIT", ModeExit, false},
+		{"test#5", "silent", ModeSilent, false},
+		{"test#6", "verbose", "", true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMode(tt.args)
+			if got != tt.want || (err != nil) != tt.wantErr {
+				t.Errorf(`ParseMode(%q) failed: got: %q, %v, want: %q, error: %t`, tt.args, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSetMode(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		mode   Mode
+		err    error
+		ignore []error
+		calls  int
+		exited int
+		want   error
+	}{
+		{"test#1", ModeLog, os.ErrExist, nil, 1, 0, nil},
+		{"test#2", ModeSilent, os.ErrExist, nil, 0, 0, nil},
+		{"test#3", ModePanic, os.ErrExist, nil, 1, 0, os.ErrExist},
+		{"test#4", ModePanic, os.ErrExist, []error{os.ErrExist}, 0, 0, nil},
+		{"test#5", ModeExit, os.ErrExist, nil, 1, 1, nil},
+		{"test#6", ModeStrict, os.ErrExist, nil, 1, 0, os.ErrExist},
+		{"test#7", ModeStrict, os.ErrExist, []error{os.ErrExist}, 1, 0, ErrUnseenIgnored},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var exited int
+			defer SetExitFunc(func(code int) { exited = code })()
+
+			var calls int
+			scope := NewScope().SetMode(tt.mode)
+			scope.RegisterCallback(func(error) { calls++ })
+
+			got := Try(func() { scope.Except(tt.err, tt.ignore...) })
+			if !errors.Is(got, tt.want) || (tt.want == nil) != (got == nil) || calls != tt.calls || exited != tt.exited {
+				t.Errorf(`Except(%v) in %s mode failed: got: %v, calls: %d, exit: %d, want: %v, calls: %d, exit: %d`,
+					tt.err, tt.mode, got, calls, exited, tt.want, tt.calls, tt.exited)
+			}
+
+			if scope.Mode() != tt.mode {
+				t.Errorf(`Mode() failed: got: %q, want: %q`, scope.Mode(), tt.mode)
+			}
+		})
+	}
+}
+
+func TestStrictModeSeen(t *testing.T) {
+	scope := NewScope().SetMode(ModeStrict)
+	scope.RegisterCallback(func(error) {})
+
+	var got []error
+	for _, err := range []error{os.ErrClosed, os.ErrClosed, fmt.Errorf("wrapped: %w", os.ErrClosed)} {
+		got = append(got, Try(func() { scope.Except(err, os.ErrClosed) }))
+	}
+
+	if !errors.Is(got[0], ErrUnseenIgnored) || got[1] != nil || !errors.Is(got[2], ErrUnseenIgnored) {
+		t.Errorf(`Except() in strict mode failed: got: %v`, got)
+	}
+
+	if len(scope.History()) != 3 || scope.History()[1].Ignored != true {
+		t.Errorf(`History() in strict mode failed: got: %+v`, scope.History())
+	}
+}
+
+func TestStrictModeSeenIgnoredOnly(t *testing.T) {
+	scope := NewScope().SetMode(ModeStrict)
+	scope.RegisterCallback(func(error) {})
+
+	_ = Try(func() { scope.Except(os.ErrClosed) })
+	if got := Try(func() { scope.Except(os.ErrClosed, os.ErrClosed) }); !errors.Is(got, ErrUnseenIgnored) {
+		t.Errorf(`Except() in strict mode failed: got: %v, want: %v`, got, ErrUnseenIgnored)
+	}
+
+	for i := 0; i < StrictSeenCapacity+1; i++ {
+		err := fmt.Errorf("id %d", i)
+		_ = Try(func() { scope.Except(err, err) })
+	}
+
+	if got := scope.seenCount.Load(); got != StrictSeenCapacity {
+		t.Errorf(`Except() in strict mode failed: got %d remembered errors, want: %d`, got, StrictSeenCapacity)
+	}
+}
@@ -0,0 +1,65 @@
+package errors
+
+// Panic value carrying error raised by Must functions.
+type mustPanic struct {
+	err error
+}
+
+// Return message of wrapped error.
+func (p *mustPanic) Error() string { return p.err.Error() }
+
+// Return wrapped error.
+func (p *mustPanic) Unwrap() error { return p.err }
+
+// Panic if error is not nil, and not among ignored ones.
+// The panic is meant to be recovered by Try or Catch.
+func Must(err error, ignore ...error) {
+	if err != nil && !isIgnored(err, ignore) {
+		panic(&mustPanic{err: err})
+	}
+}
+
+// Panic if error is not nil, and not among ignored ones.
+// Return anything from fn except for error if successful.
+// The panic is meant to be recovered by Try or Catch.
+func MustFn[T any](fn ErrorFn[T], ignore ...error) T {
+	t, err := fn()
+	Must(err, ignore...)
+	return t
+}
+
+// Panic if error is not nil, and not among ignored ones.
+// Return anything from fn except for error if successful.
+// The panic is meant to be recovered by Try or Catch.
+func MustFn2[T, U any](fn ErrorFn2[T, U], ignore ...error) (T, U) {
+	t, u, err := fn()
+	Must(err, ignore...)
+	return t, u
+}
+
+// Run fn and return error raised by Must functions within.
+// Other panics are propagated.
+func Try(fn func()) (err error) {
+	defer Catch(&err)
+	fn()
+	return nil
+}
+
+// Recover error raised by Must functions and store it in errp.
+// Other panics are propagated.
+// Must be deferred directly, e.g. defer Catch(&err).
+func Catch(errp *error) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	p, ok := r.(*mustPanic)
+	if !ok {
+		panic(r)
+	}
+
+	if errp != nil {
+		*errp = p.err
+	}
+}
@@ -0,0 +1,60 @@
+package errors
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestTry(t *testing.T) {
+	type args struct {
+		fn     ErrorFn2[any, any]
+		ignore []error
+	}
+	for _, tt := range []struct {
+		name string
+		args args
+		want error
+	}{
+		{"test#1", args{func() (any, any, error) { return &struct{}{}, &struct{}{}, nil }, nil}, nil},
+		{"test#2", args{func() (any, any, error) { return nil, nil, os.ErrExist }, nil}, os.ErrExist},
+		{"test#3", args{func() (any, any, error) { return &struct{}{}, &struct{}{}, os.ErrExist }, []error{os.ErrExist}}, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			reached := false
+			got := Try(func() {
+				v, _ := MustFn2(tt.args.fn, tt.args.ignore...)
+				_ = MustFn(W(v, nil))
+				reached = true
+			})
+
+			if !errors.Is(got, tt.want) || (got == nil) != (tt.want == nil) {
+				t.Errorf(`Try() failed: got: %v, want: %v`, got, tt.want)
+			}
+
+			if reached != (tt.want == nil) {
+				t.Errorf(`Try() failed: execution continued after failure: %t`, reached)
+			}
+		})
+	}
+}
+
+func TestCatch(t *testing.T) {
+	fn := func() (err error) {
+		defer Catch(&err)
+		Must(os.ErrExist)
+		return nil
+	}
+
+	if err := fn(); !errors.Is(err, os.ErrExist) {
+		t.Errorf(`Catch() failed: got: %v, want: %v`, err, os.ErrExist)
+	}
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf(`Try() failed: got: %v, want: foreign panic propagated`, r)
+		}
+	}()
+
+	_ = Try(func() { panic("boom") })
+}
@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Error converted from recovered panic.
+type PanicError struct {
+	// Recovered value.
+	Value any
+
+	pcs []uintptr
+}
+
+// Convert recovered value into error.
+// Errors raised by Must functions are returned as they are.
+func newPanicError(r any) error {
+	if p, ok := r.(*mustPanic); ok {
+		return p.err
+	}
+
+	return &PanicError{Value: r, pcs: callers()}
+}
+
+// Return message of recovered value.
+func (e *PanicError) Error() string { return fmt.Sprintf("panic: %v", e.Value) }
+
+// Return recovered value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Return frames of the panic, starting with the panicking function.
+func (e *PanicError) Frames() []runtime.Frame {
+	return framesOf(e.pcs)
+}
+
+// Implement fmt.Formatter.
+// Verb %+v prints the message followed by the stack trace.
+func (e *PanicError) Format(s fmt.State, verb rune) {
+	formatFrames(s, verb, e)
+}
+
+// Recover panic and handle it as *PanicError like Except.
+// Must be deferred directly, e.g. defer Recover().
+func Recover(ignore ...error) {
+	if r := recover(); r != nil {
+		defaultScope.Except(newPanicError(r), ignore...)
+	}
+}
+
+// Recover panic and handle it as *PanicError like Except.
+// Must be deferred directly, e.g. defer scope.Recover().
+func (s *Scope) Recover(ignore ...error) {
+	if r := recover(); r != nil {
+		s.Except(newPanicError(r), ignore...)
+	}
+}
+
+// Convert function into ErrorFn, which returns panics of fn as *PanicError.
+// Compose with ExceptFn to handle them, e.g. ExceptFn(SafeFn(fn)).
+func SafeFn[T any](fn func() T) ErrorFn[T] {
+	return func() (t T, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = newPanicError(r)
+			}
+		}()
+
+		return fn(), nil
+	}
+}
@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Panic with value.
+func panicWith(v any) int { panic(v) }
+
+func TestRecover(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		value    any
+		ignore   []error
+		message  string
+		target   error
+		reported bool
+	}{
+		{"test#1", "boom", nil, "panic: boom", nil, true},
+		{"test#2", os.ErrExist, nil, "panic: " + os.ErrExist.Error(), os.ErrExist, true},
+		{"test#3", os.ErrExist, []error{os.ErrExist}, "panic: " + os.ErrExist.Error(), os.ErrExist, false},
+		{"test#4", &mustPanic{err: os.ErrClosed}, nil, os.ErrClosed.Error(), os.ErrClosed, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var got error
+			scope := NewScope()
+			scope.RegisterCallback(func(err error) { got = err })
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer scope.Recover(tt.ignore...)
+				panicWith(tt.value)
+			}()
+			wg.Wait()
+
+			last := scope.LastError()
+			if last == nil || last.Error() != tt.message || (got != nil) != tt.reported {
+				t.Errorf(`Recover() failed: got: %v, reported: %v, want: %q`, last, got, tt.message)
+			}
+
+			if tt.target != nil && !errors.Is(last, tt.target) {
+				t.Errorf(`errors.Is(%v, %v) failed`, last, tt.target)
+			}
+		})
+	}
+}
+
+func TestPanicErrorFrames(t *testing.T) {
+	RegisterCallback(func(error) {})
+	defer RestoreCallback()
+
+	func() {
+		defer Recover()
+		panicWith("boom")
+	}()
+
+	var pe *PanicError
+	if !errors.As(LastError(), &pe) {
+		t.Fatalf(`Recover() failed: got: %v`, LastError())
+	}
+
+	if frames := pe.Frames(); len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "panicWith") {
+		t.Errorf(`Frames() failed: got: %v, want first frame in panicWith`, frames)
+	}
+
+	if got := fmt.Sprintf("%+v", pe); !strings.HasPrefix(got, "panic: boom\n") || !strings.Contains(got, "panic_test.go:") {
+		t.Errorf(`fmt.Sprintf("%%+v") failed: got: %q`, got)
+	}
+
+	if got := fmt.Sprintf("%-14s|%x", pe, pe); got != fmt.Sprintf("%-14s|%x", "panic: boom", "panic: boom") {
+		t.Errorf(`fmt.Sprintf("%%-14s|%%x") failed: got: %q`, got)
+	}
+}
+
+func TestSafeFn(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		fn   func() int
+		want int
+		err  string
+	}{
+		{"test#1", func() int { return 1 }, 1, ""},
+		{"test#2", func() int { return panicWith("boom") }, 0, "panic: boom"},
+		{"test#3", func() int { Must(os.ErrExist); return 1 }, 0, os.ErrExist.Error()},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SafeFn(tt.fn)()
+			if got != tt.want || (err != nil && err.Error() != tt.err) || (err == nil) != (tt.err == "") {
+				t.Errorf(`SafeFn() failed: got: %d, %v, want: %d, %q`, got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Number of attempts if not set in RetryPolicy.
+const DefaultMaxAttempts = 3
+
+// Function returning delay before next attempt,
+// given number of failed attempts so far and previous delay.
+type Backoff func(attempt int, previous time.Duration) time.Duration
+
+// Wait same delay before every attempt.
+func ConstantBackoff(delay time.Duration) Backoff {
+	return func(int, time.Duration) time.Duration { return delay }
+}
+
+// Multiply delay by multiplier after every attempt, starting with initial and capped at max.
+func ExponentialBackoff(initial, max time.Duration, multiplier float64) Backoff {
+	return func(attempt int, _ time.Duration) time.Duration {
+		delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+		if delay > float64(max) {
+			return max
+		}
+
+		return time.Duration(delay)
+	}
+}
+
+// Pick random delay between base and three times the previous delay, capped at max.
+// See "Exponential Backoff And Jitter" (AWS Architecture Blog).
+func DecorrelatedJitterBackoff(base, max time.Duration) Backoff {
+	return func(_ int, previous time.Duration) time.Duration {
+		if previous < base {
+			previous = base
+		}
+
+		delay := base + time.Duration(rand.Int63n(int64(3*previous-base)+1))
+		if delay > max {
+			return max
+		}
+
+		return delay
+	}
+}
+
+// Policy of retries.
+type RetryPolicy struct {
+	// Delay between attempts, defaults to no delay.
+	Backoff Backoff
+
+	// Maximum number of attempts.
+	// Zero defaults to DefaultMaxAttempts, negative means unlimited.
+	MaxAttempts int
+
+	// Give up if next attempt would start after that time elapsed since the first one.
+	// Zero means unlimited.
+	MaxElapsed time.Duration
+
+	// Retry only errors among these ones (see Matches), if not empty.
+	RetryOn []error
+
+	// Never retry errors among these ones (see Matches).
+	NeverRetry []error
+
+	// Source of time, defaults to system clock.
+	Clock Clock
+}
+
+// Check if error should be retried.
+func (p RetryPolicy) retryable(err error) bool {
+	if isIgnored(err, p.NeverRetry) {
+		return false
+	}
+
+	return len(p.RetryOn) == 0 || isIgnored(err, p.RetryOn)
+}
+
+// Error of failed attempt, reported to callback.
+type RetryError struct {
+	Attempt int
+	Err     error
+}
+
+// Return message of wrapped error prefixed with attempt number.
+func (e *RetryError) Error() string { return fmt.Sprintf("attempt %d: %v", e.Attempt, e.Err) }
+
+// Return wrapped error.
+func (e *RetryError) Unwrap() error { return e.Err }
+
+// Call attempt until it succeeds or policy gives up.
+// Failures are reported as *RetryError to scope carried by context.
+// Context is checked before each attempt, nil means context.Background().
+// Return last error, joined with context error if cancelled.
+func retry(ctx context.Context, policy RetryPolicy, attempt func() error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if policy.Backoff == nil {
+		policy.Backoff = ConstantBackoff(0)
+	}
+
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = DefaultMaxAttempts
+	}
+
+	if policy.Clock == nil {
+		policy.Clock = SystemClock
+	}
+
+	scope, start := ScopeFrom(ctx), policy.Clock.Now()
+
+	var (
+		delay time.Duration
+		err   error
+	)
+
+	for n := 1; ; n++ {
+		if ctx.Err() != nil {
+			if err == nil {
+				return ctx.Err()
+			}
+
+			return errors.Join(err, ctx.Err())
+		}
+
+		if err = attempt(); err == nil {
+			return nil
+		}
+
+		_ = scope.handle(&RetryError{Attempt: n, Err: err}, nil)
+		if !policy.retryable(err) || (policy.MaxAttempts > 0 && n >= policy.MaxAttempts) {
+			return err
+		}
+
+		delay = policy.Backoff(n, delay)
+		if policy.MaxElapsed > 0 && policy.Clock.Now().Add(delay).Sub(start) > policy.MaxElapsed {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+
+		case <-policy.Clock.After(delay):
+
+		}
+	}
+}
+
+// Retry fn according to policy. Every failure is reported to callback.
+// Wrapping the result with ExceptFn reports the last error once more.
+func Retry[T any](fn ErrorFn[T], policy RetryPolicy) ErrorFn[T] {
+	return RetryCtx(context.Background(), fn, policy)
+}
+
+// Retry fn according to policy until context (nil means context.Background()) is cancelled.
+// Every failure is reported to callback of scope carried by context.
+// Failures are reported regardless of mode of scope (see Mode).
+func RetryCtx[T any](ctx context.Context, fn ErrorFn[T], policy RetryPolicy) ErrorFn[T] {
+	return func() (t T, err error) {
+		err = retry(ctx, policy, func() (err error) {
+			t, err = fn()
+			return err
+		})
+
+		return t, err
+	}
+}
+
+// Retry fn according to policy. Every failure is reported to callback.
+// Wrapping the result with ExceptFn2 reports the last error once more.
+func Retry2[T, U any](fn ErrorFn2[T, U], policy RetryPolicy) ErrorFn2[T, U] {
+	return Retry2Ctx(context.Background(), fn, policy)
+}
+
+// Retry fn according to policy until context (nil means context.Background()) is cancelled.
+// Every failure is reported to callback of scope carried by context.
+// Failures are reported regardless of mode of scope (see Mode).
+func Retry2Ctx[T, U any](ctx context.Context, fn ErrorFn2[T, U], policy RetryPolicy) ErrorFn2[T, U] {
+	return func() (t T, u U, err error) {
+		err = retry(ctx, policy, func() (err error) {
+			t, u, err = fn()
+			return err
+		})
+
+		return t, u, err
+	}
+}
@@ -0,0 +1,181 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestRetry(t *testing.T) {
+	type args struct {
+		failures int
+		err      error
+		policy   RetryPolicy
+	}
+	for _, tt := range []struct {
+		name     string
+		args     args
+		want     error
+		attempts int
+		elapsed  time.Duration
+	}{
+		{"test#1", args{0, os.ErrExist, RetryPolicy{}}, nil, 1, 0},
+		{"test#2", args{2, os.ErrExist, RetryPolicy{Backoff: ConstantBackoff(time.Second)}}, nil, 3, 2 * time.Second},
+		{"test#3", args{5, os.ErrExist, RetryPolicy{Backoff: ConstantBackoff(time.Second)}}, os.ErrExist, 3, 2 * time.Second},
+		{"test#4", args{5, os.ErrExist, RetryPolicy{NeverRetry: []error{os.ErrExist}}}, os.ErrExist, 1, 0},
+		{"test#5", args{5, os.ErrExist, RetryPolicy{RetryOn: []error{os.ErrClosed}}}, os.ErrExist, 1, 0},
+		{"test#6", args{5, os.ErrExist, RetryPolicy{
+			Backoff:     ExponentialBackoff(time.Second, 4*time.Second, 2),
+			MaxAttempts: 5,
+		}}, os.ErrExist, 5, 11 * time.Second},
+		{"test#7", args{5, os.ErrExist, RetryPolicy{
+			Backoff:     ConstantBackoff(time.Minute),
+			MaxAttempts: -1,
+			MaxElapsed:  3 * time.Minute,
+		}}, os.ErrExist, 4, 3 * time.Minute},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported []error
+			scope := NewScope()
+			scope.RegisterCallback(func(err error) { reported = append(reported, err) })
+
+			start := time.Now()
+			clock := NewFakeClock(start)
+			tt.args.policy.Clock = clock
+
+			attempts := 0
+			got, err := RetryCtx(WithScope(context.Background(), scope), func() (int, error) {
+				attempts++
+				if attempts <= tt.args.failures {
+					return 0, tt.args.err
+				}
+
+				return attempts, nil
+			}, tt.args.policy)()
+
+			if !errors.Is(err, tt.want) || (err == nil) != (tt.want == nil) {
+				t.Errorf(`Retry() failed: got: %v, want: %v`, err, tt.want)
+			}
+
+			if err == nil && got != attempts {
+				t.Errorf(`Retry() failed: got: %d, want: %d`, got, attempts)
+			}
+
+			if attempts != tt.attempts {
+				t.Errorf(`Retry() failed: got %d attempts, want %d`, attempts, tt.attempts)
+			}
+
+			if failed := min(attempts, tt.args.failures); len(reported) != failed {
+				t.Errorf(`Retry() failed: got %d reports, want %d`, len(reported), failed)
+			}
+
+			for i, r := range reported {
+				var re *RetryError
+				if !errors.As(r, &re) || re.Attempt != i+1 || !errors.Is(r, tt.args.err) {
+					t.Errorf(`Retry() failed: got report: %v, want attempt %d`, r, i+1)
+				}
+			}
+
+			if elapsed := clock.Now().Sub(start); elapsed != tt.elapsed {
+				t.Errorf(`Retry() failed: got elapsed: %v, want: %v`, elapsed, tt.elapsed)
+			}
+		})
+	}
+}
+
+func TestRetryCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(WithScope(context.Background(), NewScope()))
+	ScopeFrom(ctx).RegisterCallback(func(error) { cancel() })
+
+	_, _, err := Retry2Ctx(ctx, W2(0, 0, os.ErrExist), RetryPolicy{
+		Backoff:     ConstantBackoff(time.Hour),
+		MaxAttempts: -1,
+	})()
+
+	if !errors.Is(err, os.ErrExist) || !errors.Is(err, context.Canceled) {
+		t.Errorf(`Retry2Ctx() failed: got: %v, want: %v and %v`, err, os.ErrExist, context.Canceled)
+	}
+}
+
+func TestRetryCancelled(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		failures int
+		attempts int
+		want     []error
+	}{
+		{"test#1", 0, 0, []error{context.Canceled}},
+		{"test#2", 1, 1, []error{os.ErrExist, context.Canceled}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			scope := NewScope()
+			scope.RegisterCallback(func(error) {})
+			ctx, cancel := context.WithCancel(WithScope(context.Background(), scope))
+			if tt.failures == 0 {
+				cancel()
+			}
+
+			attempts := 0
+			_, _, err := Retry2Ctx(ctx, func() (int, int, error) {
+				attempts++
+				cancel()
+				return 0, 0, os.ErrExist
+			}, RetryPolicy{MaxAttempts: -1, Clock: NewFakeClock(time.Now())})()
+
+			for _, want := range tt.want {
+				if !errors.Is(err, want) {
+					t.Errorf(`Retry2Ctx() failed: got: %v, want: %v`, err, want)
+				}
+			}
+
+			if attempts != tt.attempts {
+				t.Errorf(`Retry2Ctx() failed: got %d attempts, want %d`, attempts, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryNilContext(t *testing.T) {
+	RegisterCallback(func(error) {})
+	defer RestoreCallback()
+
+	var nilCtx context.Context
+	if got, err := RetryCtx(nilCtx, W(1, nil), RetryPolicy{})(); got != 1 || err != nil {
+		t.Errorf(`RetryCtx(nil) failed: got: %d, %v`, got, err)
+	}
+}
+
+func TestRetryPanicMode(t *testing.T) {
+	var reported int
+	scope := NewScope().SetMode(ModePanic)
+	scope.RegisterCallback(func(error) { reported++ })
+
+	attempts := 0
+	ctx := WithScope(context.Background(), scope)
+	err := Try(func() {
+		ExceptFnIn(scope, RetryCtx(ctx, func() (int, error) {
+			attempts++
+			return 0, os.ErrExist
+		}, RetryPolicy{Clock: NewFakeClock(time.Now())}))
+	})
+
+	if !errors.Is(err, os.ErrExist) || attempts != DefaultMaxAttempts || reported != DefaultMaxAttempts+1 {
+		t.Errorf(`Retry() in panic mode failed: got: %v, %d attempts, %d reports, want: %v, %d attempts, %d reports`,
+			err, attempts, reported, os.ErrExist, DefaultMaxAttempts, DefaultMaxAttempts+1)
+	}
+}
+
+func TestDecorrelatedJitterBackoff(t *testing.T) {
+	backoff := DecorrelatedJitterBackoff(time.Second, 10*time.Second)
+
+	var delay time.Duration
+	for attempt := 1; attempt <= 100; attempt++ {
+		previous := max(delay, time.Second)
+		delay = backoff(attempt, delay)
+		if delay < time.Second || delay > min(3*previous, 10*time.Second) {
+			t.Fatalf(`DecorrelatedJitterBackoff() failed: got: %v, previous: %v`, delay, previous)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Scope used by package-level functions.
+var defaultScope = NewScope()
+
+// Context key for scope.
+type scopeKey struct{}
+
+// Scope of error handling.
+// Carries its own callback, ignore list and last error store,
+// so that independent components do not interfere with each other.
+// Configuration is read lock-free from an immutable snapshot, writers replace it.
+type Scope struct {
+	state     atomic.Pointer[scopeState]
+	lastError *errorKeeper
+	history   *errorHistory
+	seen      sync.Map
+	seenCount atomic.Int64
+	mu        sync.Mutex
+}
+
+// Immutable configuration of scope.
+type scopeState struct {
+	callback  EventCallback
+	events    bool
+	callbacks []*registration
+	observers []*observation
+	nextID    uint64
+	ignore    []error
+	stack     bool
+	mode      Mode
+}
+
+// Create new scope with default callback and empty ignore list.
+func NewScope() *Scope {
+	s := &Scope{
+		lastError: &errorKeeper{},
+		history:   newErrorHistory(DefaultHistoryCapacity),
+	}
+
+	s.state.Store(&scopeState{callback: adapt((&defaultCallback{}).reset().fn)})
+	return s
+}
+
+// Replace configuration with modified copy.
+func (s *Scope) update(fn func(*scopeState)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	next := *s.state.Load()
+	fn(&next)
+	s.state.Store(&next)
+}
+
+// Retrieve scope used by package-level functions.
+func DefaultScope() *Scope { return defaultScope }
+
+// Attach scope to context.
+func WithScope(ctx context.Context, s *Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, s)
+}
+
+// Retrieve scope from context.
+// Fall back to default scope if context does not carry any.
+func ScopeFrom(ctx context.Context) *Scope {
+	if ctx != nil {
+		if s, ok := ctx.Value(scopeKey{}).(*Scope); ok && s != nil {
+			return s
+		}
+	}
+
+	return defaultScope
+}
+
+// Register custom callback to handle error.
+func (s *Scope) RegisterCallback(fn Callback) {
+	s.registerCallback(adapt(fn), false)
+}
+
+// Register custom callback to handle error events.
+func (s *Scope) RegisterEventCallback(fn EventCallback) {
+	s.registerCallback(fn, true)
+}
+
+// Register primary callback, events tells whether it needs details of events.
+func (s *Scope) registerCallback(fn EventCallback, events bool) {
+	s.update(func(state *scopeState) { state.callback, state.events = fn, events })
+}
+
+// Retrieve primary callback.
+func (s *Scope) Callback() EventCallback {
+	return s.state.Load().callback
+}
+
+// Reset callback function to fmt.Fprintln(os.Stderr, err).
+func (s *Scope) RestoreCallback() {
+	s.RegisterCallback((&defaultCallback{}).reset().fn)
+}
+
+// Add callback running before the primary one.
+// Callbacks run in order of descending priority, then in order of registration.
+func (s *Scope) AddCallback(fn Callback, opts ...CallbackOption) CallbackHandle {
+	return s.addCallback(&registration{fn: adapt(fn)}, opts)
+}
+
+// Add callback of error events running before the primary one.
+// Callbacks run in order of descending priority, then in order of registration.
+func (s *Scope) AddEventCallback(fn EventCallback, opts ...CallbackOption) CallbackHandle {
+	return s.addCallback(&registration{fn: fn, events: true}, opts)
+}
+
+// Add registration of callback, applying options.
+func (s *Scope) addCallback(r *registration, opts []CallbackOption) CallbackHandle {
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	s.update(func(state *scopeState) {
+		state.nextID++
+		r.id = state.nextID
+
+		callbacks := make([]*registration, len(state.callbacks), len(state.callbacks)+1)
+		copy(callbacks, state.callbacks)
+		callbacks = append(callbacks, r)
+		sort.SliceStable(callbacks, func(i, j int) bool { return callbacks[i].priority > callbacks[j].priority })
+		state.callbacks = callbacks
+	})
+
+	return CallbackHandle{scope: s, id: r.id}
+}
+
+// Add observer notified of every handled error, including ignored ones.
+// Observers run before callbacks, in order of registration.
+func (s *Scope) AddObserver(fn Observer) CallbackHandle {
+	o := &observation{fn: fn}
+	s.update(func(state *scopeState) {
+		state.nextID++
+		o.id = state.nextID
+		state.observers = append(append(make([]*observation, 0, len(state.observers)+1), state.observers...), o)
+	})
+
+	return CallbackHandle{scope: s, id: o.id}
+}
+
+// Remove callback or observer by its registration id.
+func (s *Scope) removeCallback(id uint64) {
+	s.update(func(state *scopeState) {
+		callbacks := make([]*registration, 0, len(state.callbacks))
+		for _, r := range state.callbacks {
+			if r.id != id {
+				callbacks = append(callbacks, r)
+			}
+		}
+
+		observers := make([]*observation, 0, len(state.observers))
+		for _, o := range state.observers {
+			if o.id != id {
+				observers = append(observers, o)
+			}
+		}
+
+		state.callbacks, state.observers = callbacks, observers
+	})
+}
+
+// Add errors to be ignored by every call within the scope.
+func (s *Scope) Ignore(ignore ...error) *Scope {
+	s.update(func(state *scopeState) {
+		state.ignore = append(append(make([]error, 0, len(state.ignore)+len(ignore)), state.ignore...), ignore...)
+	})
+
+	return s
+}
+
+// Enable or disable stack capturing.
+// Reported errors are wrapped into *StackError.
+func (s *Scope) CaptureStack(enable bool) *Scope {
+	s.update(func(state *scopeState) { state.stack = enable })
+	return s
+}
+
+// Retrieve last error.
+func (s *Scope) LastError() error {
+	return s.lastError.read()
+}
+
+// Check if last error was of this kind (see Matches).
+func (s *Scope) LastErrorWas(err error) bool {
+	return Matches(s.lastError.read(), err)
+}
+
+// Handle error if not nil, and not among ignored ones.
+// Panic or exit afterwards, if prescribed by mode of scope.
+func (s *Scope) Except(err error, ignore ...error) {
+	s.fail(s.handle(err, ignore))
+}
+
+// Handle error if not nil, and not among ignored ones.
+// Return handled error (possibly wrapped), or nil if nil or ignored.
+// Does not fail as prescribed by mode (see fail), so that
+// internal reports, e.g. of retry attempts, do not interrupt the caller.
+func (s *Scope) handle(err error, ignore []error) error {
+	if err == nil {
+		return nil
+	}
+
+	state := s.state.Load()
+
+	var st *StackError
+	if state.stack && !errors.As(err, &st) {
+		err = WithStack(err)
+	}
+
+	ignored := isIgnored(err, state.ignore, ignore)
+	if ignored && state.mode == ModeStrict && !s.seenBefore(err) {
+		err, ignored = fmt.Errorf("%w: %w", ErrUnseenIgnored, err), false
+	}
+
+	record := Record{Err: err, Code: CodeOf(err), Time: time.Now(), Ignored: ignored}
+	s.lastError.store(err)
+	s.history.push(record)
+	for _, o := range state.observers {
+		o.fn(record)
+	}
+
+	if state.mode != ModeSilent && (!ignored || state.includesIgnored()) {
+		dispatch(state.newEvent(record, ignore), state.callback, state.callbacks)
+	}
+
+	if ignored {
+		return nil
+	}
+
+	return err
+}
+
+// Check if any callback needs details of events, i.e. is not adapted from Callback.
+func (state *scopeState) wantsEvents() bool {
+	if state.events {
+		return true
+	}
+
+	for _, r := range state.callbacks {
+		if r.events {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check if any callback accepts ignored errors.
+func (state *scopeState) includesIgnored() bool {
+	for _, r := range state.callbacks {
+		if r.ignored {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Pass event to matching callbacks, then to the primary one unless propagation was stopped.
+// Events of ignored errors are passed only to callbacks including them.
+func dispatch(e Event, primary EventCallback, callbacks []*registration) {
+	for _, r := range callbacks {
+		if (e.Ignored && !r.ignored) || (r.filter != nil && !r.filter(e.Err)) {
+			continue
+		}
+
+		r.fn(e)
+		if r.stop {
+			return
+		}
+	}
+
+	if !e.Ignored {
+		primary(e)
+	}
+}
+
+// Check if error is among ignored ones.
+func isIgnored(err error, lists ...[]error) bool {
+	for _, list := range lists {
+		if Matches(err, list...) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,88 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestScopeExcept(t *testing.T) {
+	type args struct {
+		err    error
+		scoped []error
+		ignore []error
+	}
+	for _, tt := range []struct {
+		name string
+		args args
+		want string
+	}{
+		{"test#1", args{nil, nil, nil}, ""},
+		{"test#2", args{os.ErrExist, nil, nil}, os.ErrExist.Error()},
+		{"test#3", args{os.ErrExist, []error{os.ErrExist}, nil}, ""},
+		{"test#4", args{os.ErrExist, nil, []error{os.ErrExist}}, ""},
+		{"test#5", args{fmt.Errorf("wrapped: %w", os.ErrExist), []error{os.ErrExist}, nil}, ""},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buffer := bytes.NewBuffer(nil)
+			scope := NewScope().Ignore(tt.args.scoped...)
+			scope.RegisterCallback(func(err error) { buffer.WriteString(err.Error()) })
+			scope.Except(tt.args.err, tt.args.ignore...)
+			got := buffer.String()
+
+			if got != tt.want {
+				t.Errorf(`Scope.Except(%v, %v) failed: got: %q, want: %q`, tt.args.err, tt.args.ignore, got, tt.want)
+			}
+
+			if tt.args.err != nil && !scope.LastErrorWas(tt.args.err) {
+				t.Errorf(`Scope.LastError() failed: got: %v, want: %v`, scope.LastError(), tt.args.err)
+			}
+		})
+	}
+}
+
+func TestScopeIsolation(t *testing.T) {
+	a, b := NewScope(), NewScope()
+	bufferA, bufferB := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+	a.RegisterCallback(func(err error) { bufferA.WriteString(err.Error()) })
+	b.RegisterCallback(func(err error) { bufferB.WriteString(err.Error()) })
+
+	_ = ExceptFnIn(a, W[any](nil, os.ErrExist))
+	_, _ = ExceptFn2In(b, W2[any, any](nil, nil, os.ErrNotExist))
+
+	if got := bufferA.String(); got != os.ErrExist.Error() {
+		t.Errorf(`ExceptFnIn() failed: got: %q, want: %q`, got, os.ErrExist)
+	}
+
+	if got := bufferB.String(); got != os.ErrNotExist.Error() {
+		t.Errorf(`ExceptFn2In() failed: got: %q, want: %q`, got, os.ErrNotExist)
+	}
+
+	if !a.LastErrorWas(os.ErrExist) || !b.LastErrorWas(os.ErrNotExist) {
+		t.Errorf(`LastError() leaked between scopes: got: %v, %v`, a.LastError(), b.LastError())
+	}
+}
+
+func TestScopeFrom(t *testing.T) {
+	scope := NewScope()
+	for _, tt := range []struct {
+		name string
+		ctx  context.Context
+		want *Scope
+	}{
+		{"test#1", context.Background(), DefaultScope()},
+		{"test#2", WithScope(context.Background(), scope), scope},
+		{"test#3", WithScope(context.Background(), nil), DefaultScope()},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScopeFrom(tt.ctx); got != tt.want {
+				t.Errorf(`ScopeFrom() failed: got: %p, want: %p`, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package errors
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+)
+
+// Options of SlogCallback.
+type SlogOptions struct {
+	// Level of log records, defaults to slog.LevelError.
+	Level slog.Leveler
+
+	// Message of log records, defaults to "error".
+	Message string
+}
+
+// Create callback logging errors with logger.
+// Records carry group "error" with message, code, type names, wrapped messages,
+// errors.Join members and fields of FieldsCarrier errors found in the chain.
+func SlogCallback(logger *slog.Logger, opts *SlogOptions) Callback {
+	if opts == nil {
+		opts = &SlogOptions{}
+	}
+
+	level, message := slog.Leveler(slog.LevelError), "error"
+	if opts.Level != nil {
+		level = opts.Level
+	}
+
+	if opts.Message != "" {
+		message = opts.Message
+	}
+
+	return func(err error) {
+		logger.LogAttrs(context.Background(), level.Level(), message, ErrorAttr(err))
+	}
+}
+
+// Create attribute describing error chain.
+func ErrorAttr(err error) slog.Attr {
+	if err == nil {
+		return slog.Any("error", nil)
+	}
+
+	types, chain, joined, fields := describe(err)
+	args := []any{slog.String("message", err.Error()), slog.Any("types", types)}
+	if code := CodeOf(err); code != "" {
+		args = append(args, slog.String("code", string(code)))
+	}
+	if len(chain) > 0 {
+		args = append(args, slog.Any("chain", chain))
+	}
+
+	if len(joined) > 0 {
+		args = append(args, slog.Any("joined", joined))
+	}
+
+	if len(fields) > 0 {
+		keys := make([]string, 0, len(fields))
+		for key := range fields {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+		group := make([]any, 0, len(keys))
+		for _, key := range keys {
+			group = append(group, slog.Any(key, fields[key]))
+		}
+
+		args = append(args, slog.Group("fields", group...))
+	}
+
+	return slog.Group("error", args...)
+}
+
+// Handler of log/slog adding last error of scope carried by context to records.
+type LastErrorHandler struct {
+	next slog.Handler
+}
+
+// Create handler adding attribute "last_error" to records of next,
+// if scope resolved from context (see ScopeFrom) has recorded any error.
+func NewLastErrorHandler(next slog.Handler) *LastErrorHandler {
+	return &LastErrorHandler{next: next}
+}
+
+// Implement slog.Handler.
+func (h *LastErrorHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// Implement slog.Handler.
+func (h *LastErrorHandler) Handle(ctx context.Context, r slog.Record) error {
+	if err := ScopeFrom(ctx).LastError(); err != nil {
+		r = r.Clone()
+		r.AddAttrs(slog.String("last_error", err.Error()))
+	}
+
+	return h.next.Handle(ctx, r)
+}
+
+// Implement slog.Handler.
+func (h *LastErrorHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &LastErrorHandler{next: h.next.WithAttrs(attrs)}
+}
+
+// Implement slog.Handler.
+func (h *LastErrorHandler) WithGroup(name string) slog.Handler {
+	return &LastErrorHandler{next: h.next.WithGroup(name)}
+}
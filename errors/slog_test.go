@@ -0,0 +1,127 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// Error with fields for testing.
+type fieldsError struct {
+	error
+	fields map[string]any
+}
+
+func (e fieldsError) Fields() map[string]any { return e.fields }
+
+func (e fieldsError) Unwrap() error { return e.error }
+
+func TestSlogCallback(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		opts *SlogOptions
+		want map[string]any
+	}{
+		{"test#1", os.ErrExist, nil, map[string]any{
+			"level": "ERROR",
+			"msg":   "error",
+			"error": map[string]any{
+				"message": os.ErrExist.Error(),
+				"types":   []any{"*errors.errorString"},
+			},
+		}},
+		{"test#2", fmt.Errorf("wrapped: %w", os.ErrExist), &SlogOptions{Level: slog.LevelWarn, Message: "failure"}, map[string]any{
+			"level": "WARN",
+			"msg":   "failure",
+			"error": map[string]any{
+				"message": "wrapped: " + os.ErrExist.Error(),
+				"types":   []any{"*fmt.wrapError", "*errors.errorString"},
+				"chain":   []any{os.ErrExist.Error()},
+			},
+		}},
+		{"test#3", errors.Join(fieldsError{os.ErrExist, map[string]any{"path": "file.txt"}}, os.ErrClosed), nil, map[string]any{
+			"level": "ERROR",
+			"msg":   "error",
+			"error": map[string]any{
+				"message": os.ErrExist.Error() + "\n" + os.ErrClosed.Error(),
+				"types":   []any{"*errors.joinError", "errors.fieldsError", "*errors.errorString", "*errors.errorString"},
+				"chain":   []any{os.ErrExist.Error()},
+				"joined":  []any{os.ErrExist.Error(), os.ErrClosed.Error()},
+				"fields":  map[string]any{"path": "file.txt"},
+			},
+		}},
+		{"test#4", With(os.ErrExist, "attempt", 2), nil, map[string]any{
+			"level": "ERROR",
+			"msg":   "error",
+			"error": map[string]any{
+				"message": os.ErrExist.Error(),
+				"types":   []any{"*errors.withFields", "*errors.errorString"},
+				"chain":   []any{os.ErrExist.Error()},
+				"fields":  map[string]any{"attempt": float64(2)},
+			},
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := bytes.NewBuffer(nil)
+			logger := slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if len(groups) == 0 && a.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+
+					return a
+				},
+			}))
+
+			SlogCallback(logger, tt.opts)(tt.err)
+
+			var got map[string]any
+			if err := json.Unmarshal(buffer.Bytes(), &got); err != nil {
+				t.Fatalf(`json.Unmarshal(%q) failed: %v`, buffer, err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf(`SlogCallback()(%v) failed: got: %v, want: %v`, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLastErrorHandler(t *testing.T) {
+	scope := NewScope()
+	scope.RegisterCallback(func(error) {})
+	scope.Except(os.ErrExist)
+
+	for _, tt := range []struct {
+		name string
+		ctx  context.Context
+		want string
+	}{
+		{"test#1", WithScope(context.Background(), NewScope()), ""},
+		{"test#2", WithScope(context.Background(), scope), os.ErrExist.Error()},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			buffer := bytes.NewBuffer(nil)
+			logger := slog.New(NewLastErrorHandler(slog.NewJSONHandler(buffer, nil)))
+			logger.InfoContext(tt.ctx, "served")
+
+			var got struct {
+				LastError string `json:"last_error"`
+			}
+			if err := json.Unmarshal(buffer.Bytes(), &got); err != nil {
+				t.Fatalf(`json.Unmarshal(%q) failed: %v`, buffer, err)
+			}
+
+			if got.LastError != tt.want {
+				t.Errorf(`LastErrorHandler failed: got: %q, want: %q`, buffer, tt.want)
+			}
+		})
+	}
+}
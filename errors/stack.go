@@ -0,0 +1,123 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Maximum depth of captured stack.
+const maxStackDepth = 64
+
+// Import path of this package, used to skip internal frames.
+var packagePath = reflect.TypeOf(Scope{}).PkgPath()
+
+// Error wrapper carrying stack of the call site.
+type StackError struct {
+	err error
+	pcs []uintptr
+}
+
+// Wrap error with the stack of the caller.
+// Frames belonging to this package are skipped.
+// Return nil for nil error.
+func WithStack(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &StackError{err: err, pcs: callers()}
+}
+
+// Capture stack of the caller, skipping leading frames
+// belonging to this package or to the runtime (e.g. of a panic).
+func callers() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	pcs = pcs[:runtime.Callers(2, pcs)]
+
+	for len(pcs) > 0 {
+		frame, _ := runtime.CallersFrames(pcs[:1]).Next()
+		if isUserFrame(frame) {
+			break
+		}
+
+		pcs = pcs[1:]
+	}
+
+	return pcs
+}
+
+// Check if frame belongs to this package (excluding tests).
+func isInternalFrame(frame runtime.Frame) bool {
+	return strings.HasPrefix(frame.Function, packagePath+".") && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// Check if frame belongs neither to this package nor to the runtime.
+func isUserFrame(frame runtime.Frame) bool {
+	return !isInternalFrame(frame) && !strings.HasPrefix(frame.Function, "runtime.")
+}
+
+// Resolve frames of program counters.
+func framesOf(pcs []uintptr) []runtime.Frame {
+	result := make([]runtime.Frame, 0, len(pcs))
+	if len(pcs) == 0 {
+		return result
+	}
+
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		result = append(result, frame)
+		if !more {
+			break
+		}
+	}
+
+	return result
+}
+
+// Write frames, one function and file:line pair per frame.
+func writeTrace(w io.Writer, frames []runtime.Frame) {
+	for _, frame := range frames {
+		_, _ = fmt.Fprintf(w, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+	}
+}
+
+// Return message of wrapped error.
+func (e *StackError) Error() string { return e.err.Error() }
+
+// Return wrapped error.
+func (e *StackError) Unwrap() error { return e.err }
+
+// Return captured frames, starting with the call site.
+func (e *StackError) Frames() []runtime.Frame {
+	return framesOf(e.pcs)
+}
+
+// Implement fmt.Formatter.
+// Verb %+v prints the message followed by the stack trace.
+func (e *StackError) Format(s fmt.State, verb rune) {
+	formatFrames(s, verb, e)
+}
+
+// Format error carrying frames, see StackError.Format.
+// Verbs other than %+v apply to the message, as if it was a string.
+func formatFrames(s fmt.State, verb rune, err interface {
+	error
+	Frames() []runtime.Frame
+}) {
+	if verb == 'v' && s.Flag('+') {
+		_, _ = io.WriteString(s, err.Error())
+		writeTrace(s, err.Frames())
+		return
+	}
+
+	_, _ = fmt.Fprintf(s, fmt.FormatString(s, verb), err.Error())
+}
+
+// Enable or disable stack capturing in default scope.
+func CaptureStack(enable bool) {
+	defaultScope.CaptureStack(enable)
+}
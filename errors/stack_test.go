@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestWithStack(t *testing.T) {
+	if WithStack(nil) != nil {
+		t.Errorf(`WithStack(nil) failed: got non-nil`)
+	}
+
+	err := WithStack(os.ErrExist)
+	if !errors.Is(err, os.ErrExist) {
+		t.Errorf(`errors.Is(%v, %v) failed`, err, os.ErrExist)
+	}
+
+	var st *StackError
+	if !errors.As(err, &st) {
+		t.Fatalf(`errors.As(%v, %T) failed`, err, st)
+	}
+
+	frames := st.Frames()
+	if len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "TestWithStack") {
+		t.Errorf(`Frames() failed: got: %v, want first frame in TestWithStack`, frames)
+	}
+
+	for _, tt := range []struct {
+		name   string
+		format string
+		want   func(string) bool
+	}{
+		{"test#1", "%v", func(s string) bool { return s == os.ErrExist.Error() }},
+		{"test#2", "%s", func(s string) bool { return s == os.ErrExist.Error() }},
+		{"test#3", "%q", func(s string) bool { return s == fmt.Sprintf("%q", os.ErrExist.Error()) }},
+		{"test#4", "%+v", func(s string) bool {
+			return strings.HasPrefix(s, os.ErrExist.Error()+"\n") && strings.Contains(s, "stack_test.go:")
+		}},
+		{"test#5", "%20v", func(s string) bool { return s == fmt.Sprintf("%20v", os.ErrExist.Error()) }},
+		{"test#6", "%x", func(s string) bool { return s == fmt.Sprintf("%x", os.ErrExist.Error()) }},
+		{"test#7", "%d", func(s string) bool { return s == "%!d(string="+os.ErrExist.Error()+")" }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := fmt.Sprintf(tt.format, err); !tt.want(got) {
+				t.Errorf(`fmt.Sprintf(%q, err) failed: got: %q`, tt.format, got)
+			}
+		})
+	}
+}
+
+func TestScopeCaptureStack(t *testing.T) {
+	var got error
+	scope := NewScope().CaptureStack(true)
+	scope.RegisterCallback(func(err error) { got = err })
+
+	_ = ExceptFnIn(scope, W[any](nil, os.ErrExist))
+
+	var st *StackError
+	if !errors.As(got, &st) || !errors.Is(got, os.ErrExist) {
+		t.Fatalf(`ExceptFnIn() failed: got: %#v, want *StackError wrapping %v`, got, os.ErrExist)
+	}
+
+	if frames := st.Frames(); len(frames) == 0 || !strings.HasSuffix(frames[0].Function, "TestScopeCaptureStack") {
+		t.Errorf(`Frames() failed: got: %v, want first frame in TestScopeCaptureStack`, frames)
+	}
+
+	if !scope.LastErrorWas(os.ErrExist) {
+		t.Errorf(`LastErrorWas(%v) failed: got: %v`, os.ErrExist, scope.LastError())
+	}
+}
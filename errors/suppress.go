@@ -0,0 +1,253 @@
+package errors
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Window of suppression if not set in SuppressOptions.
+const DefaultSuppressWindow = time.Second
+
+// Options of Suppressor.
+type SuppressOptions struct {
+	// Period for which similar errors are suppressed after the first one,
+	// defaults to DefaultSuppressWindow.
+	Window time.Duration
+
+	// Errors passed per second (token bucket), zero means unlimited.
+	Rate float64
+
+	// Size of token bucket, defaults to 1.
+	Burst int
+
+	// Source of time, defaults to system clock.
+	// Drives the timer of windows, so in tests use ManualClock rather than FakeClock.
+	Clock Clock
+}
+
+// Summary of suppressed errors, passed to callback once window of suppression expires.
+type SuppressedError struct {
+	// Last suppressed error.
+	Err error
+
+	// Number of suppressed errors.
+	Count int
+}
+
+// Return summary message.
+func (e *SuppressedError) Error() string {
+	return fmt.Sprintf("suppressed %d similar errors: %v", e.Count, e.Err)
+}
+
+// Return last suppressed error.
+func (e *SuppressedError) Unwrap() error { return e.Err }
+
+// Occurrences of similar errors within window.
+type suppressEntry struct {
+	first time.Time
+	count int
+	last  error
+}
+
+// Callback wrapper deduplicating and rate limiting errors (thread-safe).
+// Errors are similar if they share type, message and call site.
+// Summaries are passed once windows expire, from a background timer if no further error arrives,
+// so the wrapped callback must be thread-safe.
+type Suppressor struct {
+	next     Callback
+	opts     SuppressOptions
+	entries  map[string]*suppressEntry
+	tokens   float64
+	refill   time.Time
+	watching bool
+	stopped  bool
+	stop     chan struct{}
+	wake     chan struct{}
+	sync.Mutex
+}
+
+// Create suppressor passing errors to next.
+// Register its Handle method as callback, e.g. RegisterCallback(NewSuppressor(fn, opts).Handle).
+func NewSuppressor(next Callback, opts SuppressOptions) *Suppressor {
+	if opts.Window <= 0 {
+		opts.Window = DefaultSuppressWindow
+	}
+
+	if opts.Burst <= 0 {
+		opts.Burst = 1
+	}
+
+	if opts.Clock == nil {
+		opts.Clock = SystemClock
+	}
+
+	return &Suppressor{
+		next:    next,
+		opts:    opts,
+		entries: make(map[string]*suppressEntry),
+		tokens:  float64(opts.Burst),
+		refill:  opts.Clock.Now(),
+		stop:    make(chan struct{}),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Pass error to next callback unless suppressed.
+// Summaries of expired windows are passed first.
+func (s *Suppressor) Handle(err error) {
+	key := fingerprint(err)
+	now := s.opts.Clock.Now()
+
+	s.Lock()
+	summaries := s.expire(now, false)
+	pass := false
+	if e, ok := s.entries[key]; ok {
+		e.count, e.last = e.count+1, err
+		if e.count == 1 {
+			// window may expire earlier than the one being waited for
+			select {
+			case s.wake <- struct{}{}:
+			default:
+			}
+		}
+	} else if s.take(now) {
+		s.entries[key], pass = &suppressEntry{first: now}, true
+	} else {
+		s.entries[key] = &suppressEntry{first: now, count: 1, last: err}
+	}
+
+	if !pass && !s.watching && !s.stopped {
+		s.watching = true
+		go s.watch()
+	}
+	s.Unlock()
+
+	for _, summary := range summaries {
+		s.next(summary)
+	}
+
+	if pass {
+		s.next(err)
+	}
+}
+
+// Pass summaries of all suppressed errors to next callback and reset windows.
+func (s *Suppressor) Flush() {
+	s.Lock()
+	summaries := s.expire(s.opts.Clock.Now(), true)
+	s.Unlock()
+
+	for _, summary := range summaries {
+		s.next(summary)
+	}
+}
+
+// Stop timer of windows and pass summaries of all suppressed errors to next callback.
+// Afterwards, summaries are passed only by Handle and Flush. Safe to call multiple times.
+func (s *Suppressor) Stop() {
+	s.Lock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.stop)
+	}
+	s.Unlock()
+
+	s.Flush()
+}
+
+// Pass summaries of windows as they expire, until none is pending or suppressor is stopped.
+func (s *Suppressor) watch() {
+	for {
+		s.Lock()
+		deadline, ok := s.deadline()
+		if !ok || s.stopped {
+			s.watching = false
+			s.Unlock()
+			return
+		}
+
+		wait := deadline.Sub(s.opts.Clock.Now())
+		s.Unlock()
+
+		select {
+		case <-s.opts.Clock.After(wait):
+
+		case <-s.wake:
+			continue
+
+		case <-s.stop:
+			s.Lock()
+			s.watching = false
+			s.Unlock()
+			return
+
+		}
+
+		s.Lock()
+		summaries := s.expire(s.opts.Clock.Now(), false)
+		s.Unlock()
+
+		for _, summary := range summaries {
+			s.next(summary)
+		}
+	}
+}
+
+// Return earliest expiry of window with suppressed errors (caller must hold the lock).
+func (s *Suppressor) deadline() (time.Time, bool) {
+	var earliest time.Time
+	for _, e := range s.entries {
+		if e.count > 0 && (earliest.IsZero() || e.first.Before(earliest)) {
+			earliest = e.first
+		}
+	}
+
+	return earliest.Add(s.opts.Window), !earliest.IsZero()
+}
+
+// Remove entries with expired window (or all, if forced) and return their summaries
+// (caller must hold the lock).
+func (s *Suppressor) expire(now time.Time, force bool) []error {
+	var summaries []error
+	for key, e := range s.entries {
+		if !force && now.Sub(e.first) < s.opts.Window {
+			continue
+		}
+
+		if e.count > 0 {
+			summaries = append(summaries, &SuppressedError{Err: e.last, Count: e.count})
+		}
+
+		delete(s.entries, key)
+	}
+
+	return summaries
+}
+
+// Take token from bucket (caller must hold the lock).
+func (s *Suppressor) take(now time.Time) bool {
+	if s.opts.Rate <= 0 {
+		return true
+	}
+
+	s.tokens = min(float64(s.opts.Burst), s.tokens+now.Sub(s.refill).Seconds()*s.opts.Rate)
+	s.refill = now
+	if s.tokens < 1 {
+		return false
+	}
+
+	s.tokens--
+	return true
+}
+
+// Create fingerprint of error from its type, message and call site.
+// Call site is taken from captured stack, if any, or from the current one.
+func fingerprint(err error) string {
+	var site string
+	if frame, ok := callerFrame(err); ok {
+		site = fmt.Sprintf("%s:%d", frame.File, frame.Line)
+	}
+
+	return fmt.Sprintf("%T\x00%s\x00%s", err, err.Error(), site)
+}
@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Callback collecting errors passed by suppressor, possibly from its timer.
+type collected struct {
+	errs []error
+	sync.Mutex
+}
+
+func (c *collected) add(err error) {
+	c.Lock()
+	c.errs = append(c.errs, err)
+	c.Unlock()
+}
+
+// Wait until n errors are collected (or a second passed) and return them.
+func (c *collected) wait(n int) []error {
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(time.Millisecond) {
+		c.Lock()
+		got := append([]error(nil), c.errs...)
+		c.Unlock()
+		if len(got) >= n || time.Now().After(deadline) {
+			return got
+		}
+	}
+}
+
+func TestSuppressor(t *testing.T) {
+	clock := NewManualClock(time.Now())
+
+	var c collected
+	scope := NewScope()
+	suppressor := NewSuppressor(c.add, SuppressOptions{Window: time.Minute, Clock: clock})
+	defer suppressor.Stop()
+	scope.RegisterCallback(suppressor.Handle)
+
+	for i := 0; i < 100; i++ {
+		scope.Except(os.ErrExist)
+	}
+
+	scope.Except(os.ErrExist) // different call site
+	scope.Except(os.ErrNotExist)
+
+	if got := c.wait(3); len(got) != 3 || got[0] != os.ErrExist || got[1] != os.ErrExist || got[2] != os.ErrNotExist {
+		t.Fatalf(`Handle() failed: got: %v`, got)
+	}
+
+	if n := len(scope.History()); n != DefaultHistoryCapacity || !scope.LastErrorWas(os.ErrNotExist) {
+		t.Errorf(`LastError() failed: got: %v, history: %d`, scope.LastError(), n)
+	}
+
+	clock.Advance(time.Minute)
+	c.wait(4)
+	scope.Except(os.ErrClosed)
+
+	var summary *SuppressedError
+	if got := c.wait(5); len(got) != 5 || !errors.As(got[3], &summary) || summary.Count != 99 || !errors.Is(summary, os.ErrExist) || got[4] != os.ErrClosed {
+		t.Errorf(`Handle() failed: got: %v, want summary of 99 errors`, got)
+	}
+}
+
+func TestSuppressorRate(t *testing.T) {
+	clock := NewManualClock(time.Now())
+
+	var c collected
+	suppressor := NewSuppressor(c.add, SuppressOptions{Rate: 1, Burst: 2, Clock: clock})
+	defer suppressor.Stop()
+
+	errs := []error{os.ErrExist, os.ErrNotExist, os.ErrClosed, os.ErrClosed}
+	for _, err := range errs {
+		suppressor.Handle(err)
+	}
+
+	if got := c.wait(2); len(got) != 2 {
+		t.Fatalf(`Handle() failed: got: %v, want 2 errors`, got)
+	}
+
+	clock.Advance(DefaultSuppressWindow)
+	c.wait(3)
+	suppressor.Handle(os.ErrPermission)
+
+	var summary *SuppressedError
+	if got := c.wait(4); len(got) != 4 || !errors.As(got[2], &summary) || summary.Count != 2 || !errors.Is(summary, os.ErrClosed) || got[3] != os.ErrPermission {
+		t.Fatalf(`Handle() failed: got: %v`, got)
+	}
+
+	suppressor.Handle(os.ErrPermission)
+	suppressor.Flush()
+	if got := c.wait(5); len(got) != 5 || !errors.As(got[4], &summary) || summary.Count != 1 || !errors.Is(summary, os.ErrPermission) {
+		t.Errorf(`Flush() failed: got: %v`, got)
+	}
+}
+
+func TestSuppressorTimer(t *testing.T) {
+	clock := NewManualClock(time.Now())
+
+	var c collected
+	suppressor := NewSuppressor(c.add, SuppressOptions{Window: time.Minute, Clock: clock})
+	for i := 0; i < 10; i++ {
+		suppressor.Handle(os.ErrExist)
+	}
+
+	clock.Advance(time.Hour)
+
+	var summary *SuppressedError
+	if got := c.wait(2); len(got) != 2 || !errors.As(got[1], &summary) || summary.Count != 9 {
+		t.Errorf(`Handle() failed: got: %v, want summary of 9 errors without further error`, got)
+	}
+
+	for i := 0; i < 2; i++ {
+		suppressor.Handle(os.ErrExist)
+	}
+
+	suppressor.Stop()
+	suppressor.Stop()
+	if got := c.wait(4); len(got) != 4 || !errors.As(got[3], &summary) || summary.Count != 1 {
+		t.Errorf(`Stop() failed: got: %v, want summary of 1 error`, got)
+	}
+}
@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Context key for span recorder.
+type spanKey struct{}
+
+// Resolver of span recorder from context, see SetSpanResolver.
+var spanResolver atomic.Pointer[func(context.Context) SpanRecorder]
+
+// Span of trace recording handled errors,
+// e.g. adapter of an OpenTelemetry span.
+type SpanRecorder interface {
+	// Record error along with its fields (see Fields).
+	RecordError(err error, fields map[string]any)
+	// Set status of span, derived from error (see CanonicalOf).
+	SetStatus(code Canonical, description string)
+}
+
+// Return copy of context carrying span recorder.
+func WithSpanRecorder(ctx context.Context, r SpanRecorder) context.Context {
+	return context.WithValue(ctx, spanKey{}, r)
+}
+
+// Set function resolving span recorder from context,
+// consulted if context does not carry one (see WithSpanRecorder).
+// Nil removes the resolver.
+func SetSpanResolver(fn func(context.Context) SpanRecorder) {
+	if fn == nil {
+		spanResolver.Store(nil)
+		return
+	}
+
+	spanResolver.Store(&fn)
+}
+
+// Retrieve span recorder from context, or nil if there is none (or context is nil).
+func SpanRecorderFrom(ctx context.Context) SpanRecorder {
+	if ctx == nil {
+		return nil
+	}
+
+	if r, ok := ctx.Value(spanKey{}).(SpanRecorder); ok {
+		return r
+	}
+
+	if fn := spanResolver.Load(); fn != nil {
+		return (*fn)(ctx)
+	}
+
+	return nil
+}
+
+// Handle error if not nil, and not among ignored ones, in scope of context (see ScopeFrom).
+// Record handled error on span of context (see SpanRecorderFrom),
+// before failing as prescribed by mode of scope.
+func ExceptCtx(ctx context.Context, err error, ignore ...error) {
+	scope := ScopeFrom(ctx)
+	if err = scope.handle(err, ignore); err == nil {
+		return
+	}
+
+	if r := SpanRecorderFrom(ctx); r != nil {
+		code := CanonicalOf(err)
+		if code == CanonicalOK {
+			code = CanonicalUnknown
+		}
+
+		r.RecordError(err, Fields(err))
+		r.SetStatus(code, err.Error())
+	}
+
+	scope.fail(err)
+}
+
+// Handle error if not nil, and not among ignored ones, in scope of context (see ScopeFrom).
+// Record handled error on span of context (see SpanRecorderFrom).
+// Return anything from fn except for error if successful.
+func ExceptFnCtx[T any](ctx context.Context, fn ErrorFn[T], ignore ...error) T {
+	t, err := fn()
+	ExceptCtx(ctx, err, ignore...)
+	return t
+}
@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+)
+
+// Span recorder remembering last recorded error and status.
+type testSpan struct {
+	err         error
+	fields      map[string]any
+	code        Canonical
+	description string
+}
+
+func (s *testSpan) RecordError(err error, fields map[string]any) { s.err, s.fields = err, fields }
+
+func (s *testSpan) SetStatus(code Canonical, description string) {
+	s.code, s.description = code, description
+}
+
+var errTestTraced = Register(CodedError{Code: "test.trace.unavailable", Message: "unavailable", Canonical: CanonicalUnavailable})
+
+func TestExceptCtx(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		err    error
+		ignore []error
+		want   testSpan
+	}{
+		{"test#1", nil, nil, testSpan{}},
+		{"test#2", os.ErrExist, []error{os.ErrExist}, testSpan{}},
+		{"test#3", os.ErrExist, nil, testSpan{err: os.ErrExist, code: CanonicalUnknown, description: os.ErrExist.Error()}},
+		{"test#4", With(os.ErrClosed, "id", 1), nil, testSpan{err: os.ErrClosed, fields: map[string]any{"id": 1}, code: CanonicalUnknown}},
+		{"test#5", errTestTraced.New("down"), nil, testSpan{err: errTestTraced, code: CanonicalUnavailable}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			scope := NewScope()
+			scope.RegisterCallback(func(error) {})
+
+			span := &testSpan{}
+			ctx := WithSpanRecorder(WithScope(context.Background(), scope), span)
+			ExceptCtx(ctx, tt.err, tt.ignore...)
+
+			switch {
+			case !Matches(span.err, tt.want.err) || (tt.want.err == nil) != (span.err == nil):
+				t.Errorf(`ExceptCtx(%v) failed: got: %v, want: %v`, tt.err, span.err, tt.want.err)
+			case fmt.Sprint(span.fields) != fmt.Sprint(tt.want.fields):
+				t.Errorf(`ExceptCtx(%v) failed: got: %v, want: %v`, tt.err, span.fields, tt.want.fields)
+			case span.code != tt.want.code || (tt.want.description != "" && span.description != tt.want.description):
+				t.Errorf(`ExceptCtx(%v) failed: got: %v %q, want: %v %q`, tt.err, span.code, span.description, tt.want.code, tt.want.description)
+			case scope.LastError() != tt.err && tt.err != nil:
+				t.Errorf(`ExceptCtx(%v) failed: got: last error %v`, tt.err, scope.LastError())
+			}
+		})
+	}
+}
+
+func TestSpanRecorderFrom(t *testing.T) {
+	span := &testSpan{}
+	defer SetSpanResolver(nil)
+
+	if got := SpanRecorderFrom(context.Background()); got != nil {
+		t.Errorf(`SpanRecorderFrom() failed: got: %v, want: nil`, got)
+	}
+
+	SetSpanResolver(func(context.Context) SpanRecorder { return span })
+	if got := SpanRecorderFrom(context.Background()); got != span {
+		t.Errorf(`SpanRecorderFrom() failed: got: %v, want: %v`, got, span)
+	}
+
+	other := &testSpan{}
+	if got := SpanRecorderFrom(WithSpanRecorder(context.Background(), other)); got != other {
+		t.Errorf(`SpanRecorderFrom() failed: got: %v, want: %v`, got, other)
+	}
+
+	scope := NewScope()
+	scope.RegisterCallback(func(error) {})
+	if got := ExceptFnCtx(WithScope(context.Background(), scope), W(1, os.ErrClosed)); got != 1 || span.err != os.ErrClosed {
+		t.Errorf(`ExceptFnCtx() failed: got: %d, %v`, got, span.err)
+	}
+}
+
+func TestExceptCtxPanicMode(t *testing.T) {
+	scope := NewScope().SetMode(ModePanic)
+	scope.RegisterCallback(func(error) {})
+
+	span := &testSpan{}
+	ctx := WithSpanRecorder(WithScope(context.Background(), scope), span)
+	if err := Try(func() { ExceptCtx(ctx, os.ErrExist) }); err != os.ErrExist || span.err != os.ErrExist {
+		t.Errorf(`ExceptCtx() in panic mode failed: got: %v, span: %v, want: %v`, err, span.err, os.ErrExist)
+	}
+
+	var nilCtx context.Context
+	if got := SpanRecorderFrom(nilCtx); got != nil {
+		t.Errorf(`SpanRecorderFrom(nil) failed: got: %v, want: nil`, got)
+	}
+}
@@ -1,3 +1,5 @@
+//go:generate go run ../cmd/arity -pkg errors -max 4
+
 package errors
 
 type (
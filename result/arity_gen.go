@@ -0,0 +1,52 @@
+// Code generated by cmd/arity; DO NOT EDIT.
+
+package result
+
+import (
+	supererrors "github.com/sarumaj/go-super/errors"
+)
+
+// Tuple of 2 values.
+type Tuple2[T1, T2 any] struct {
+	V1 T1
+	V2 T2
+}
+
+// Get result of function returning 2 values and error.
+func GetResult2[T1, T2 any](fn supererrors.ErrorFn2[T1, T2], ignore ...error) *Result[Tuple2[T1, T2]] {
+	return GetResult(func() (Tuple2[T1, T2], error) {
+		t1, t2, err := fn()
+		return Tuple2[T1, T2]{V1: t1, V2: t2}, err
+	}, ignore...)
+}
+
+// Tuple of 3 values.
+type Tuple3[T1, T2, T3 any] struct {
+	V1 T1
+	V2 T2
+	V3 T3
+}
+
+// Get result of function returning 3 values and error.
+func GetResult3[T1, T2, T3 any](fn supererrors.ErrorFn3[T1, T2, T3], ignore ...error) *Result[Tuple3[T1, T2, T3]] {
+	return GetResult(func() (Tuple3[T1, T2, T3], error) {
+		t1, t2, t3, err := fn()
+		return Tuple3[T1, T2, T3]{V1: t1, V2: t2, V3: t3}, err
+	}, ignore...)
+}
+
+// Tuple of 4 values.
+type Tuple4[T1, T2, T3, T4 any] struct {
+	V1 T1
+	V2 T2
+	V3 T3
+	V4 T4
+}
+
+// Get result of function returning 4 values and error.
+func GetResult4[T1, T2, T3, T4 any](fn supererrors.ErrorFn4[T1, T2, T3, T4], ignore ...error) *Result[Tuple4[T1, T2, T3, T4]] {
+	return GetResult(func() (Tuple4[T1, T2, T3, T4], error) {
+		t1, t2, t3, t4, err := fn()
+		return Tuple4[T1, T2, T3, T4]{V1: t1, V2: t2, V3: t3, V4: t4}, err
+	}, ignore...)
+}
@@ -0,0 +1,98 @@
+// Code generated by cmd/arity; DO NOT EDIT.
+
+package result
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetResult2(t *testing.T) {
+	testError := errors.New("test")
+
+	type output = Tuple2[any, any]
+	type args struct {
+		o   output
+		err error
+	}
+
+	for _, tt := range []struct {
+		name   string
+		args   args
+		ignore []error
+		want   *Result[output]
+	}{
+		{"test#1", args{output{1, 1}, nil}, nil, &Result[output]{state: Success, value: output{1, 1}}},
+		{"test#2", args{output{}, testError}, nil, &Result[output]{state: Failure, fault: testError}},
+		{"test#3", args{output{}, testError}, []error{testError}, &Result[output]{state: ExpectedFailure}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GetResult2(func() (any, any, error) {
+				return tt.args.o.V1, tt.args.o.V2, tt.args.err
+			}, tt.ignore...)
+			if !result.Equals(*tt.want) {
+				t.Errorf("GetResult2() = %v, want %v", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetResult3(t *testing.T) {
+	testError := errors.New("test")
+
+	type output = Tuple3[any, any, any]
+	type args struct {
+		o   output
+		err error
+	}
+
+	for _, tt := range []struct {
+		name   string
+		args   args
+		ignore []error
+		want   *Result[output]
+	}{
+		{"test#1", args{output{1, 1, 1}, nil}, nil, &Result[output]{state: Success, value: output{1, 1, 1}}},
+		{"test#2", args{output{}, testError}, nil, &Result[output]{state: Failure, fault: testError}},
+		{"test#3", args{output{}, testError}, []error{testError}, &Result[output]{state: ExpectedFailure}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GetResult3(func() (any, any, any, error) {
+				return tt.args.o.V1, tt.args.o.V2, tt.args.o.V3, tt.args.err
+			}, tt.ignore...)
+			if !result.Equals(*tt.want) {
+				t.Errorf("GetResult3() = %v, want %v", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetResult4(t *testing.T) {
+	testError := errors.New("test")
+
+	type output = Tuple4[any, any, any, any]
+	type args struct {
+		o   output
+		err error
+	}
+
+	for _, tt := range []struct {
+		name   string
+		args   args
+		ignore []error
+		want   *Result[output]
+	}{
+		{"test#1", args{output{1, 1, 1, 1}, nil}, nil, &Result[output]{state: Success, value: output{1, 1, 1, 1}}},
+		{"test#2", args{output{}, testError}, nil, &Result[output]{state: Failure, fault: testError}},
+		{"test#3", args{output{}, testError}, []error{testError}, &Result[output]{state: ExpectedFailure}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			result := GetResult4(func() (any, any, any, any, error) {
+				return tt.args.o.V1, tt.args.o.V2, tt.args.o.V3, tt.args.o.V4, tt.args.err
+			}, tt.ignore...)
+			if !result.Equals(*tt.want) {
+				t.Errorf("GetResult4() = %v, want %v", result, tt.want)
+			}
+		})
+	}
+}
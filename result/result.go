@@ -1,3 +1,5 @@
+//go:generate go run ../cmd/arity -pkg result -max 4
+
 package result
 
 import (
@@ -69,10 +71,8 @@ func GetResult[T any](fn supererrors.ErrorFn[T], ignore ...error) *Result[T] {
 		return r.SetState(Success)
 	}
 
-	for _, e := range ignore {
-		if errors.Is(err, e) {
-			return r.SetState(ExpectedFailure).SetError(nil)
-		}
+	if supererrors.Matches(err, ignore...) {
+		return r.SetState(ExpectedFailure).SetError(nil)
 	}
 
 	return r.SetState(Failure)
@@ -3,6 +3,8 @@ package result
 import (
 	"errors"
 	"testing"
+
+	supererrors "github.com/sarumaj/go-super/errors"
 )
 
 func TestResultWorkflow(t *testing.T) {
@@ -33,3 +35,14 @@ func TestResultWorkflow(t *testing.T) {
 	}
 
 }
+
+func TestGetResultMatcher(t *testing.T) {
+	testError := errors.New("test")
+
+	result := GetResult(func() (any, error) { return nil, testError }, supererrors.MatchFunc(func(err error) bool {
+		return err == testError
+	}))
+	if result.State() != ExpectedFailure || result.Error() != nil {
+		t.Errorf("GetResult() = %v, want %v", result, ExpectedFailure)
+	}
+}